@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sysmon/system-monitor-cli/internal/check"
+	"github.com/sysmon/system-monitor-cli/internal/collector"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+var (
+	checkSubsystems []string
+	checkWarnings   []string
+	checkWarnRatio  float64
+	checkTimeout    time.Duration
+)
+
+// checkSampleCount is the number of samples a check takes. Rates need two.
+const checkSampleCount = 2
+
+func init() {
+	checkCmd.Flags().StringSliceVar(&checkSubsystems, "subsystems", nil,
+		"subsystems to check: "+strings.Join(check.Subsystems, ", ")+" (default all)")
+	checkCmd.Flags().StringArrayVar(&checkWarnings, "warning", nil, "warning level for a metric as metric=value, e.g. disk=80 (repeatable)")
+	checkCmd.Flags().Float64Var(&checkWarnRatio, "warning-ratio", config.DefaultWarningRatio,
+		"fraction of the critical level at which other metrics warn")
+	checkCmd.Flags().DurationVar(&checkTimeout, "timeout", 10*time.Second, "time after which the check gives up with UNKNOWN")
+
+	// Flag errors must still produce plugin output and an UNKNOWN status
+	checkCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		exitCheck(&check.Report{Unknown: []string{err.Error()}})
+		return nil
+	})
+	rootCmd.AddCommand(checkCmd)
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check thresholds once as a Nagios or Icinga plugin",
+	Long: `Take two samples --interval apart, so CPU and network rates cover a full
+interval, and compare the second against warning and critical levels.
+
+The critical levels are the thresholds (--cpu-threshold, thresholds in the
+config file, ...). Each metric warns at --warning-ratio of its critical level
+unless given its own level with --warning or check.warning in the config file.
+
+Prints one line of plugin output with performance data and exits 0, 1, 2 or 3
+for OK, WARNING, CRITICAL or UNKNOWN.`,
+	Run: runCheck,
+}
+
+// runCheck runs the check and exits with its status
+func runCheck(cmd *cobra.Command, args []string) {
+	if len(args) > 0 {
+		exitCheck(&check.Report{Unknown: []string{"unexpected arguments: " + strings.Join(args, " ")}})
+	}
+
+	cfg, err := loadCheckConfig(cmd)
+	if err != nil {
+		exitCheck(&check.Report{Unknown: []string{err.Error()}})
+	}
+
+	checker, err := check.NewChecker(&cfg.Thresholds, &cfg.Check, checkSubsystems)
+	if err != nil {
+		exitCheck(&check.Report{Unknown: []string{err.Error()}})
+	}
+
+	// Neither processes nor containers are checked, so skip collecting them
+	cfg.TopProcesses = 0
+	cfg.Docker = false
+	metricsCollector, err := newCollector(cfg)
+	if err != nil {
+		exitCheck(&check.Report{Unknown: []string{err.Error()}})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	metrics, failed, err := sampleForCheck(ctx, metricsCollector, cfg.Interval)
+	if err != nil {
+		exitCheck(&check.Report{Unknown: []string{err.Error()}})
+	}
+	exitCheck(checker.Evaluate(metrics, failed))
+}
+
+// loadCheckConfig loads the configuration and applies the check flags
+func loadCheckConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Flags().Changed("warning-ratio") {
+		cfg.Check.WarningRatio = checkWarnRatio
+	}
+	if len(checkWarnings) > 0 {
+		// Flag levels are added to those in the config file
+		warnings := make(map[string]float64, len(cfg.Check.Warning)+len(checkWarnings))
+		for metric, level := range cfg.Check.Warning {
+			warnings[metric] = level
+		}
+		for _, flag := range checkWarnings {
+			metric, value, ok := strings.Cut(flag, "=")
+			level, err := strconv.ParseFloat(value, 64)
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid --warning %q, want metric=value", flag)
+			}
+			warnings[metric] = level
+		}
+		cfg.Check.Warning = warnings
+	}
+
+	if err := config.ValidateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// sampleForCheck collects checkSampleCount snapshots interval apart and
+// returns the last, with the subsystems whose collection failed for it
+func sampleForCheck(ctx context.Context, c *collector.Collector, interval time.Duration) (*models.Metrics, map[string]bool, error) {
+	var metrics *models.Metrics
+	var failed map[string]bool
+
+	for i := 0; i < checkSampleCount; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return nil, nil, fmt.Errorf("check timed out after %v", checkTimeout)
+			}
+		}
+
+		// Collection does not stop when ctx is done, so wait for it separately
+		before := c.Stats().Errors
+		done := make(chan error, 1)
+		go func() {
+			var err error
+			metrics, err = c.Collect(ctx)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err != nil {
+				return nil, nil, fmt.Errorf("collection failed: %w", err)
+			}
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("check timed out after %v", checkTimeout)
+		}
+
+		failed = make(map[string]bool)
+		for subsystem, n := range c.Stats().Errors {
+			if n > before[subsystem] {
+				failed[subsystem] = true
+			}
+		}
+	}
+	return metrics, failed, nil
+}
+
+// exitCheck prints the plugin output and exits with the report's status
+func exitCheck(report *check.Report) {
+	fmt.Println(report)
+	os.Exit(int(report.Status()))
+}
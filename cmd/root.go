@@ -11,21 +11,47 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/sysmon/system-monitor-cli/internal/collector"
 	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/docker"
 	"github.com/sysmon/system-monitor-cli/internal/logger"
 	"github.com/sysmon/system-monitor-cli/internal/monitor"
+	"github.com/sysmon/system-monitor-cli/internal/notify"
 	"github.com/sysmon/system-monitor-cli/internal/render"
 	"github.com/sysmon/system-monitor-cli/internal/stats"
+	"github.com/sysmon/system-monitor-cli/internal/tui"
 )
 
 var (
 	// Flag variables
-	cfgFile       string
-	interval      time.Duration
-	jsonMode      bool
-	logFile       string
-	cpuThreshold  float64
-	memThreshold  float64
-	diskThreshold float64
+	cfgFile          string
+	interval         time.Duration
+	jsonMode         bool
+	interactive      bool
+	once             bool
+	sampleCount      int
+	runDuration      time.Duration
+	historySize      int
+	logFile          string
+	topProcesses     int
+	processSort      string
+	procRoot         string
+	sysRoot          string
+	hostRoot         string
+	cgroupPath       string
+	cgroupLimits     bool
+	dockerEnabled    bool
+	dockerSocket     string
+	webhooks         []string
+	cpuThreshold     float64
+	memThreshold     float64
+	diskThreshold    float64
+	swapThreshold    float64
+	iowaitThreshold  float64
+	stealThreshold   float64
+	inodeThreshold   float64
+	fdThreshold      float64
+	pidThreshold     float64
+	netErrThreshold  float64
+	netDropThreshold float64
 
 	// Version information
 	Version = "1.0.0"
@@ -53,27 +79,123 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path (YAML or JSON)")
 	rootCmd.PersistentFlags().DurationVar(&interval, "interval", 1*time.Second, "refresh interval (e.g., 1s, 500ms, 2m)")
 	rootCmd.PersistentFlags().BoolVar(&jsonMode, "json", false, "output metrics as JSON")
+	rootCmd.PersistentFlags().BoolVar(&interactive, "interactive", false, "enable keyboard control of the terminal display (press h for keys)")
+	rootCmd.PersistentFlags().BoolVar(&once, "once", false, "output a single sample after a warm-up sample, then exit (same as --count 1)")
+	rootCmd.PersistentFlags().IntVar(&sampleCount, "count", 0, "exit after outputting this many samples, preceded by a warm-up sample (0 for no limit)")
+	rootCmd.PersistentFlags().DurationVar(&runDuration, "duration", 0, "exit after this long, e.g. 30s or 1h, starting with a warm-up sample (0 for no limit)")
+	rootCmd.PersistentFlags().IntVar(&historySize, "history", 60, "number of recent samples graphed as sparklines (0 disables graphs)")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "path to log file for metrics export")
+	rootCmd.PersistentFlags().StringVar(&procRoot, "proc-root", "/proc", "procfs root, e.g. /host/proc inside a container (env SYSMON_PROC_ROOT)")
+	rootCmd.PersistentFlags().StringVar(&sysRoot, "sys-root", "/sys", "sysfs root, e.g. /host/sys inside a container (env SYSMON_SYS_ROOT)")
+	rootCmd.PersistentFlags().StringVar(&hostRoot, "host-root", "/", "host root filesystem mount for disk usage, e.g. /host inside a container (env SYSMON_HOST_ROOT)")
+	rootCmd.PersistentFlags().StringVar(&cgroupPath, "cgroup-path", "", "cgroup v2 path to account (default: sysmon's own cgroup)")
+	rootCmd.PersistentFlags().BoolVar(&cgroupLimits, "cgroup-limits", false, "report headline CPU and memory percentages against cgroup limits")
+	rootCmd.PersistentFlags().BoolVar(&dockerEnabled, "docker", false, "show per-container statistics from the Docker Engine API")
+	rootCmd.PersistentFlags().StringVar(&dockerSocket, "docker-socket", "", "Docker Engine API unix socket (default "+docker.DefaultSocket+")")
+	rootCmd.PersistentFlags().StringArrayVar(&webhooks, "webhook", nil, "URL to POST alert notifications to (repeatable)")
+	rootCmd.PersistentFlags().IntVar(&topProcesses, "top", 10, "number of processes to show in the process table (0 to disable)")
+	rootCmd.PersistentFlags().StringVar(&processSort, "sort", "cpu", "process table sort order (cpu or memory)")
 	rootCmd.PersistentFlags().Float64Var(&cpuThreshold, "cpu-threshold", 80.0, "CPU usage alert threshold (0-100)")
 	rootCmd.PersistentFlags().Float64Var(&memThreshold, "mem-threshold", 85.0, "memory usage alert threshold (0-100)")
 	rootCmd.PersistentFlags().Float64Var(&diskThreshold, "disk-threshold", 90.0, "disk usage alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&swapThreshold, "swap-threshold", 50.0, "swap usage alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&iowaitThreshold, "iowait-threshold", 20.0, "CPU iowait alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&stealThreshold, "steal-threshold", 10.0, "CPU steal time alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&inodeThreshold, "inode-threshold", 90.0, "filesystem inode usage alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&fdThreshold, "fd-threshold", 80.0, "system-wide file descriptor usage alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&pidThreshold, "pid-threshold", 80.0, "PID and thread table usage alert threshold (0-100)")
+	rootCmd.PersistentFlags().Float64Var(&netErrThreshold, "net-error-threshold", 1.0, "network errors per second alert threshold, per interface")
+	rootCmd.PersistentFlags().Float64Var(&netDropThreshold, "net-drop-threshold", 10.0, "network drops per second alert threshold, per interface")
 }
 
 // runMonitor is the main execution function for the monitor command
 func runMonitor(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	metricsCollector, err := newCollector(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create renderer based on mode. Frames of bounded runs are written
+	// inline so they are still on the terminal after exit.
+	var renderer render.Renderer
+	if cfg.JSONMode {
+		renderer = render.NewJSONRenderer(os.Stdout)
+	} else {
+		terminal := render.NewTerminalRenderer(os.Stdout, &cfg.Thresholds)
+		terminal.SetInline(cfg.Bounded())
+		renderer = terminal
+	}
+
+	// Create monitor
+	metricsLogger := newLogger(cfg)
+	mon := monitor.NewSystemMonitor(cfg, metricsCollector, renderer, metricsLogger)
+	if err := addNotifiers(mon, cfg, metricsLogger); err != nil {
+		return err
+	}
+
+	// Enable keyboard control; raw mode disables Ctrl+C signals, so the
+	// monitor handles Ctrl+C as a quit key
+	if cfg.Interactive {
+		input, err := tui.NewInput(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if err := mon.SetInteractive(input, tui.NewController(cfg.Interval, cfg.ProcessSort)); err != nil {
+			input.Close()
+			return err
+		}
+	}
+
+	return runUntilSignal(mon)
+}
+
+// loadConfig builds the configuration from the config file, the
+// environment and the command-line flags, in increasing precedence
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
 	// Load configuration from file if specified
 	cfg, err := config.LoadFromFile(cfgFile)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	// Apply environment overrides
+	config.ApplyEnvironment(cfg)
+
 	// Check which flags were explicitly set
 	intervalSet := cmd.Flags().Changed("interval")
 	jsonSet := cmd.Flags().Changed("json")
+	interactiveSet := cmd.Flags().Changed("interactive")
+	onceSet := cmd.Flags().Changed("once")
+	countSet := cmd.Flags().Changed("count")
+	durationSet := cmd.Flags().Changed("duration")
+	historySet := cmd.Flags().Changed("history")
 	logFileSet := cmd.Flags().Changed("log-file")
+	procRootSet := cmd.Flags().Changed("proc-root")
+	sysRootSet := cmd.Flags().Changed("sys-root")
+	hostRootSet := cmd.Flags().Changed("host-root")
+	cgroupPathSet := cmd.Flags().Changed("cgroup-path")
+	cgroupLimitsSet := cmd.Flags().Changed("cgroup-limits")
+	dockerSet := cmd.Flags().Changed("docker")
+	dockerSocketSet := cmd.Flags().Changed("docker-socket")
+	webhookSet := cmd.Flags().Changed("webhook")
+	topSet := cmd.Flags().Changed("top")
+	sortSet := cmd.Flags().Changed("sort")
 	cpuThresholdSet := cmd.Flags().Changed("cpu-threshold")
 	memThresholdSet := cmd.Flags().Changed("mem-threshold")
 	diskThresholdSet := cmd.Flags().Changed("disk-threshold")
+	swapThresholdSet := cmd.Flags().Changed("swap-threshold")
+	iowaitThresholdSet := cmd.Flags().Changed("iowait-threshold")
+	stealThresholdSet := cmd.Flags().Changed("steal-threshold")
+	inodeThresholdSet := cmd.Flags().Changed("inode-threshold")
+	fdThresholdSet := cmd.Flags().Changed("fd-threshold")
+	pidThresholdSet := cmd.Flags().Changed("pid-threshold")
+	netErrThresholdSet := cmd.Flags().Changed("net-error-threshold")
+	netDropThresholdSet := cmd.Flags().Changed("net-drop-threshold")
 
 	// Merge with command-line flags (flags take precedence)
 	if intervalSet {
@@ -82,9 +204,60 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	if jsonSet {
 		cfg.JSONMode = jsonMode
 	}
+	if interactiveSet {
+		cfg.Interactive = interactive
+	}
+	if onceSet && countSet {
+		return nil, fmt.Errorf("--once and --count cannot be combined")
+	}
+	if onceSet && once {
+		cfg.Count = 1
+	}
+	if countSet {
+		cfg.Count = sampleCount
+	}
+	if durationSet {
+		cfg.Duration = runDuration
+	}
+	if historySet {
+		cfg.History = historySize
+	}
 	if logFileSet {
 		cfg.LogFile = logFile
 	}
+	if procRootSet {
+		cfg.ProcRoot = procRoot
+	}
+	if sysRootSet {
+		cfg.SysRoot = sysRoot
+	}
+	if hostRootSet {
+		cfg.HostRoot = hostRoot
+	}
+	if cgroupPathSet {
+		cfg.CgroupPath = cgroupPath
+	}
+	if cgroupLimitsSet {
+		cfg.CgroupLimits = cgroupLimits
+	}
+	if dockerSet {
+		cfg.Docker = dockerEnabled
+	}
+	if dockerSocketSet {
+		cfg.DockerSocket = dockerSocket
+	}
+	if webhookSet {
+		// Flag webhooks are added to those in the config file
+		for _, url := range webhooks {
+			cfg.Notify.Webhooks = append(cfg.Notify.Webhooks, config.Webhook{URL: url})
+		}
+	}
+	if topSet {
+		cfg.TopProcesses = topProcesses
+	}
+	if sortSet {
+		cfg.ProcessSort = processSort
+	}
 	if cpuThresholdSet {
 		cfg.Thresholds.CPU = cpuThreshold
 	}
@@ -94,42 +267,108 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	if diskThresholdSet {
 		cfg.Thresholds.Disk = diskThreshold
 	}
+	if swapThresholdSet {
+		cfg.Thresholds.Swap = swapThreshold
+	}
+	if iowaitThresholdSet {
+		cfg.Thresholds.IOWait = iowaitThreshold
+	}
+	if stealThresholdSet {
+		cfg.Thresholds.Steal = stealThreshold
+	}
+	if inodeThresholdSet {
+		cfg.Thresholds.Inodes = inodeThreshold
+	}
+	if fdThresholdSet {
+		cfg.Thresholds.FileDescriptors = fdThreshold
+	}
+	if pidThresholdSet {
+		cfg.Thresholds.PIDs = pidThreshold
+	}
+	if netErrThresholdSet {
+		cfg.Thresholds.NetErrors = netErrThreshold
+	}
+	if netDropThresholdSet {
+		cfg.Thresholds.NetDrops = netDropThreshold
+	}
 
 	// Validate final configuration
 	if err := config.ValidateConfig(cfg); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	return cfg, nil
+}
+
+// newCollector creates the metrics collector for the configured platform
+// and options
+func newCollector(cfg *config.Config) (*collector.Collector, error) {
 	// Create system stats provider
-	provider, err := stats.NewProvider()
+	provider, err := stats.NewProvider(stats.Options{
+		ProcRoot:   cfg.ProcRoot,
+		SysRoot:    cfg.SysRoot,
+		HostRoot:   cfg.HostRoot,
+		CgroupPath: cfg.CgroupPath,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create stats provider: %w", err)
+		return nil, fmt.Errorf("failed to create stats provider: %w", err)
 	}
 
 	// Create metrics collector
 	metricsCollector := collector.NewCollector(provider)
+	metricsCollector.SetProcessOptions(cfg.TopProcesses, cfg.ProcessSort)
+	metricsCollector.SetCgroupLimits(cfg.CgroupLimits)
+	if cfg.Docker {
+		metricsCollector.SetContainerProvider(docker.NewClient(cfg.DockerSocket))
+	}
 
-	// Create renderer based on mode
-	var renderer render.Renderer
-	if cfg.JSONMode {
-		renderer = render.NewJSONRenderer(os.Stdout)
-	} else {
-		renderer = render.NewTerminalRenderer(os.Stdout, &cfg.Thresholds)
+	return metricsCollector, nil
+}
+
+// newLogger creates the metrics logger if a log file is configured. A
+// logger that cannot be created is reported and skipped.
+func newLogger(cfg *config.Config) logger.Logger {
+	if cfg.LogFile == "" {
+		return nil
+	}
+
+	metricsLogger, err := logger.NewFileLogger(cfg.LogFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create logger: %v\n", err)
+		return nil
+	}
+	return metricsLogger
+}
+
+// addNotifiers sends the monitor's alert transitions to the configured
+// webhooks and command hooks. Hook failures are written to metricsLogger.
+func addNotifiers(mon *monitor.SystemMonitor, cfg *config.Config, metricsLogger logger.Logger) error {
+	if len(cfg.Notify.Webhooks) == 0 && len(cfg.Notify.Hooks) == 0 {
+		return nil
 	}
 
-	// Create logger if log file specified
-	var metricsLogger logger.Logger
-	if cfg.LogFile != "" {
-		metricsLogger, err = logger.NewFileLogger(cfg.LogFile)
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	for _, hook := range cfg.Notify.Webhooks {
+		webhook, err := notify.NewWebhook(hook, cfg.Notify, host)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to create logger: %v\n", err)
-			metricsLogger = nil
+			return err
 		}
+		mon.AddNotifier(webhook)
 	}
 
-	// Create monitor
-	mon := monitor.NewSystemMonitor(cfg, metricsCollector, renderer, metricsLogger)
+	if len(cfg.Notify.Hooks) > 0 {
+		mon.AddNotifier(notify.NewCommandRunner(cfg.Notify.Hooks, cfg.Notify.HookConcurrency, host, metricsLogger))
+	}
+	return nil
+}
 
+// runUntilSignal runs the monitor until it stops or an interrupt or
+// termination signal arrives, then cleans up
+func runUntilSignal(mon *monitor.SystemMonitor) error {
 	// Set up context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
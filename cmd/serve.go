@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sysmon/system-monitor-cli/internal/exporter"
+	"github.com/sysmon/system-monitor-cli/internal/monitor"
+)
+
+var listenAddr string
+
+func init() {
+	serveCmd.Flags().StringVar(&listenAddr, "listen", ":9110", "address to serve Prometheus metrics on")
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve metrics for Prometheus to scrape",
+	Long: `Collect metrics at the configured interval and serve the latest snapshot at
+/metrics in the Prometheus text exposition format. Scrapes do not trigger
+collections, so the scrape interval and --interval are independent.`,
+	RunE: runServe,
+}
+
+// shutdownTimeout bounds how long in-flight scrapes may take to finish
+const shutdownTimeout = 5 * time.Second
+
+// runServe runs the monitor with the Prometheus exporter as its renderer
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("listen") {
+		cfg.Listen = listenAddr
+	}
+	if cfg.Interactive {
+		return fmt.Errorf("interactive mode cannot be combined with serve")
+	}
+
+	metricsCollector, err := newCollector(cfg)
+	if err != nil {
+		return err
+	}
+
+	exp := exporter.NewPrometheusExporter(metricsCollector)
+	metricsLogger := newLogger(cfg)
+	mon := monitor.NewSystemMonitor(cfg, metricsCollector, exp, metricsLogger)
+	if err := addNotifiers(mon, cfg, metricsLogger); err != nil {
+		return err
+	}
+
+	// Listen before starting the monitor so a bad address fails at once
+	listener, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Listen, err)
+	}
+
+	server := &http.Server{
+		Handler:           exp.Handler(),
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "Error: metrics server failed: %v\n", err)
+		}
+	}()
+	fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", listener.Addr())
+
+	runErr := runUntilSignal(mon)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil && runErr == nil {
+		runErr = err
+	}
+	return runErr
+}
@@ -0,0 +1,61 @@
+// Package alert turns threshold crossings into alerts that move through
+// pending, firing and resolved states.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// State is the lifecycle state of an alert
+type State string
+
+// Alert states. An alert is pending while its threshold is exceeded but
+// its "for" duration has not yet passed, firing once it has, and resolved
+// when the value falls back to the clear threshold (or, while pending, to
+// the threshold itself).
+const (
+	StateInactive State = "inactive"
+	StatePending  State = "pending"
+	StateFiring   State = "firing"
+	StateResolved State = "resolved"
+)
+
+// Event records a state transition of one alert
+type Event struct {
+	Metric    string    `json:"metric"`             // Metric name, e.g. "disk" (see config.AlertMetrics)
+	Instance  string    `json:"instance,omitempty"` // Mountpoint, interface or sensor; empty for host-wide metrics
+	State     State     `json:"state"`              // State entered
+	Previous  State     `json:"previous"`           // State left
+	Value     float64   `json:"value"`              // Value that caused the transition
+	Threshold float64   `json:"threshold"`          // Threshold the value is compared against
+	Time      time.Time `json:"time"`               // Sample time of the transition
+	Since     time.Time `json:"since"`              // When the threshold was first exceeded
+}
+
+// Name identifies the alert, e.g. "disk /" or "cpu"
+func (e Event) Name() string {
+	if e.Instance == "" {
+		return e.Metric
+	}
+	return e.Metric + " " + e.Instance
+}
+
+// String describes the transition for logs and displays
+func (e Event) String() string {
+	switch e.State {
+	case StateResolved:
+		return fmt.Sprintf("%s resolved: %.2f (threshold %.2f, %s %s)",
+			e.Name(), e.Value, e.Threshold, e.Previous, e.Time.Sub(e.Since).Round(time.Second))
+	default:
+		return fmt.Sprintf("%s %s: %.2f > %.2f", e.Name(), e.State, e.Value, e.Threshold)
+	}
+}
+
+// Notifier delivers alert transitions to an external system
+type Notifier interface {
+	// Notify delivers one event. It may block until delivery completes or
+	// ctx is cancelled.
+	Notify(ctx context.Context, event Event) error
+}
@@ -0,0 +1,147 @@
+package alert
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// key identifies one alert
+type key struct {
+	metric   string
+	instance string
+}
+
+// Engine evaluates the configured thresholds against each sample and
+// tracks the state of every alert. It is not safe for concurrent use.
+type Engine struct {
+	alerts *config.Alerts
+	rules  []rule
+	active map[key]*Event // Pending and firing alerts, as of their last event
+}
+
+// NewEngine creates an engine for the given thresholds and alert rules.
+// Both are read on every evaluation.
+func NewEngine(thresholds *config.Thresholds, alerts *config.Alerts) *Engine {
+	return &Engine{
+		alerts: alerts,
+		rules:  thresholdRules(thresholds),
+		active: make(map[key]*Event),
+	}
+}
+
+// Evaluate checks a snapshot against every rule and returns the state
+// transitions it causes, in rule order. Alerts whose instance is missing
+// from the snapshot, such as an unmounted filesystem, are resolved.
+func (e *Engine) Evaluate(metrics *models.Metrics) []Event {
+	var events []Event
+	seen := make(map[key]bool)
+
+	for _, r := range e.rules {
+		settings := e.alerts.Rule(r.metric)
+		for _, s := range r.samples(metrics) {
+			k := key{r.metric, s.instance}
+			seen[k] = true
+			if event, ok := e.update(k, s, settings, metrics.Timestamp); ok {
+				events = append(events, event)
+			}
+		}
+	}
+
+	var gone []key
+	for k := range e.active {
+		if !seen[k] {
+			gone = append(gone, k)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool {
+		if gone[i].metric != gone[j].metric {
+			return gone[i].metric < gone[j].metric
+		}
+		return gone[i].instance < gone[j].instance
+	})
+	for _, k := range gone {
+		events = append(events, e.transition(k, StateResolved, e.active[k].Value, e.active[k].Threshold, metrics.Timestamp))
+	}
+
+	return events
+}
+
+// update advances the state of one alert with a new sample, returning
+// the transition if there is one
+func (e *Engine) update(k key, s sample, settings config.AlertRule, now time.Time) (Event, bool) {
+	exceeded := s.value > s.threshold
+
+	current, ok := e.active[k]
+	if !ok {
+		if !exceeded {
+			return Event{}, false
+		}
+		e.active[k] = &Event{Metric: k.metric, Instance: k.instance, State: StateInactive, Since: now}
+		if settings.For <= 0 {
+			return e.transition(k, StateFiring, s.value, s.threshold, now), true
+		}
+		return e.transition(k, StatePending, s.value, s.threshold, now), true
+	}
+
+	switch current.State {
+	case StatePending:
+		if !exceeded {
+			return e.transition(k, StateResolved, s.value, s.threshold, now), true
+		}
+		if now.Sub(current.Since) >= settings.For {
+			return e.transition(k, StateFiring, s.value, s.threshold, now), true
+		}
+	case StateFiring:
+		// Hysteresis: a firing alert only resolves at its clear threshold
+		clear := settings.Clear
+		if clear <= 0 || clear > s.threshold {
+			clear = s.threshold
+		}
+		if s.value <= clear {
+			return e.transition(k, StateResolved, s.value, s.threshold, now), true
+		}
+	}
+
+	current.Value = s.value
+	current.Threshold = s.threshold
+	return Event{}, false
+}
+
+// transition moves an alert to a new state and returns the event.
+// Resolved alerts are forgotten.
+func (e *Engine) transition(k key, state State, value, threshold float64, now time.Time) Event {
+	current := e.active[k]
+	event := Event{
+		Metric:    k.metric,
+		Instance:  k.instance,
+		State:     state,
+		Previous:  current.State,
+		Value:     value,
+		Threshold: threshold,
+		Time:      now,
+		Since:     current.Since,
+	}
+
+	if state == StateResolved {
+		delete(e.active, k)
+	} else {
+		*current = event
+	}
+	return event
+}
+
+// Active returns the pending and firing alerts as of their latest
+// samples, sorted by name
+func (e *Engine) Active() []Event {
+	active := make([]Event, 0, len(e.active))
+	for _, event := range e.active {
+		active = append(active, *event)
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Name() < active[j].Name()
+	})
+	return active
+}
@@ -0,0 +1,157 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// cpuSample returns a snapshot with the given overall CPU usage, taken
+// the given number of seconds after start
+func cpuSample(seconds int, cpu float64) *models.Metrics {
+	return &models.Metrics{
+		Timestamp: start.Add(time.Duration(seconds) * time.Second),
+		CPU:       models.CPUStats{Overall: cpu},
+	}
+}
+
+// states returns the states entered by a list of events
+func states(events []Event) []State {
+	var result []State
+	for _, e := range events {
+		result = append(result, e.State)
+	}
+	return result
+}
+
+func TestEngineTransitions(t *testing.T) {
+	thresholds := config.NewDefaultConfig().Thresholds
+	thresholds.CPU = 80
+	alerts := config.Alerts{
+		Rules: map[string]config.AlertRule{
+			config.MetricCPU: {For: 10 * time.Second, Clear: 70},
+		},
+	}
+	e := NewEngine(&thresholds, &alerts)
+
+	steps := []struct {
+		name    string
+		seconds int
+		cpu     float64
+		want    State // Expected transition, empty for none
+	}{
+		{"below threshold", 0, 50, ""},
+		{"crossing starts pending", 5, 90, StatePending},
+		{"still pending", 10, 95, ""},
+		{"fires after the for duration", 15, 85, StateFiring},
+		{"stays firing above clear", 20, 75, ""},
+		{"resolves at clear", 25, 70, StateResolved},
+		{"pending again", 30, 81, StatePending},
+		{"brief spike does not fire", 35, 60, StateResolved},
+	}
+
+	for _, step := range steps {
+		events := e.Evaluate(cpuSample(step.seconds, step.cpu))
+		var got State
+		switch len(events) {
+		case 0:
+		case 1:
+			got = events[0].State
+		default:
+			t.Fatalf("%s: got %d events %v, want at most one", step.name, len(events), states(events))
+		}
+		if got != step.want {
+			t.Errorf("%s: transition = %q, want %q", step.name, got, step.want)
+		}
+	}
+}
+
+func TestEngineEventDetails(t *testing.T) {
+	thresholds := config.NewDefaultConfig().Thresholds
+	e := NewEngine(&thresholds, &config.Alerts{})
+
+	events := e.Evaluate(&models.Metrics{
+		Timestamp: start,
+		Disk: []models.DiskStats{
+			{Mountpoint: "/", Percent: 95},
+			{Mountpoint: "/home", Percent: 40},
+		},
+	})
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	// Without a for duration the alert fires at once
+	want := Event{
+		Metric:    config.MetricDisk,
+		Instance:  "/",
+		State:     StateFiring,
+		Previous:  StateInactive,
+		Value:     95,
+		Threshold: thresholds.Disk,
+		Time:      start,
+		Since:     start,
+	}
+	if events[0] != want {
+		t.Errorf("event = %+v, want %+v", events[0], want)
+	}
+	if active := e.Active(); len(active) != 1 || active[0].Name() != "disk /" {
+		t.Errorf("Active() = %+v, want the disk / alert", active)
+	}
+
+	// The filesystem disappearing resolves its alert
+	events = e.Evaluate(&models.Metrics{Timestamp: start.Add(time.Minute)})
+	if len(events) != 1 || events[0].State != StateResolved || events[0].Previous != StateFiring {
+		t.Errorf("after unmount got %+v, want disk / resolved from firing", events)
+	}
+	if active := e.Active(); len(active) != 0 {
+		t.Errorf("Active() = %+v, want none", active)
+	}
+}
+
+func TestEngineSensorsWithSameName(t *testing.T) {
+	thresholds := config.NewDefaultConfig().Thresholds
+	e := NewEngine(&thresholds, &config.Alerts{})
+
+	// Two drives report the same chip and label; only the hot one alerts,
+	// and it keeps firing rather than being resolved by the cool one
+	sample := func(seconds int) *models.Metrics {
+		return &models.Metrics{
+			Timestamp: start.Add(time.Duration(seconds) * time.Second),
+			Sensors: models.SensorStats{Temperatures: []models.TemperatureSensor{
+				{Chip: "nvme", Device: "hwmon1", Label: "Composite", Current: 40, Critical: 80},
+				{Chip: "nvme", Device: "hwmon2", Label: "Composite", Current: 85, Critical: 80},
+			}},
+		}
+	}
+
+	events := e.Evaluate(sample(0))
+	if len(events) != 1 || events[0].State != StateFiring || events[0].Instance != "nvme/Composite (hwmon2)" {
+		t.Fatalf("first sample events = %+v, want nvme/Composite (hwmon2) firing", events)
+	}
+	for seconds := 1; seconds <= 3; seconds++ {
+		if events := e.Evaluate(sample(seconds)); len(events) != 0 {
+			t.Errorf("sample %d: events = %+v, want none", seconds, events)
+		}
+	}
+}
+
+func TestEngineDefaultFor(t *testing.T) {
+	thresholds := config.NewDefaultConfig().Thresholds
+	alerts := config.Alerts{For: time.Minute}
+	e := NewEngine(&thresholds, &alerts)
+
+	if got := states(e.Evaluate(cpuSample(0, 99))); len(got) != 1 || got[0] != StatePending {
+		t.Errorf("first crossing = %v, want pending", got)
+	}
+	if got := states(e.Evaluate(cpuSample(30, 99))); len(got) != 0 {
+		t.Errorf("before the default for duration = %v, want no transition", got)
+	}
+	if got := states(e.Evaluate(cpuSample(60, 99))); len(got) != 1 || got[0] != StateFiring {
+		t.Errorf("after the default for duration = %v, want firing", got)
+	}
+}
@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// sample is one value of a metric to compare against its threshold
+type sample struct {
+	instance  string
+	value     float64
+	threshold float64
+}
+
+// rule extracts the samples of one metric from a snapshot
+type rule struct {
+	metric  string
+	samples func(m *models.Metrics) []sample
+}
+
+// thresholdRules builds a rule for every configured threshold. The rules
+// read t on each evaluation, so later changes to t take effect. Per-core
+// CPU usage is not alerted on; only the overall figure is.
+func thresholdRules(t *config.Thresholds) []rule {
+	single := func(metric string, value func(m *models.Metrics) float64, threshold *float64) rule {
+		return rule{metric, func(m *models.Metrics) []sample {
+			return []sample{{value: value(m), threshold: *threshold}}
+		}}
+	}
+
+	return []rule{
+		single(config.MetricCPU, func(m *models.Metrics) float64 { return m.CPU.Overall }, &t.CPU),
+		single(config.MetricIOWait, func(m *models.Metrics) float64 { return m.CPU.Breakdown.IOWait }, &t.IOWait),
+		single(config.MetricSteal, func(m *models.Metrics) float64 { return m.CPU.Breakdown.Steal }, &t.Steal),
+		single(config.MetricMemory, func(m *models.Metrics) float64 { return m.Memory.Percent }, &t.Memory),
+		single(config.MetricSwap, func(m *models.Metrics) float64 { return m.Memory.Swap.Percent }, &t.Swap),
+
+		{config.MetricDisk, func(m *models.Metrics) []sample {
+			samples := make([]sample, 0, len(m.Disk))
+			for _, d := range m.Disk {
+				samples = append(samples, sample{d.Mountpoint, d.Percent, t.Disk})
+			}
+			return samples
+		}},
+		{config.MetricInodes, func(m *models.Metrics) []sample {
+			samples := make([]sample, 0, len(m.Disk))
+			for _, d := range m.Disk {
+				if d.InodesTotal > 0 {
+					samples = append(samples, sample{d.Mountpoint, d.InodesPercent, t.Inodes})
+				}
+			}
+			return samples
+		}},
+
+		{config.MetricFileDescriptors, func(m *models.Metrics) []sample {
+			if m.Kernel.FilesMax == 0 {
+				return nil
+			}
+			return []sample{{value: m.Kernel.FilesPercent, threshold: t.FileDescriptors}}
+		}},
+		{config.MetricPIDs, func(m *models.Metrics) []sample {
+			if m.Kernel.PIDMax == 0 {
+				return nil
+			}
+			// The PID and thread tables share a threshold; the fuller one counts
+			return []sample{{value: max(m.Kernel.PIDPercent, m.Kernel.ThreadPercent), threshold: t.PIDs}}
+		}},
+
+		{config.MetricNetErrors, func(m *models.Metrics) []sample {
+			samples := make([]sample, 0, len(m.Network))
+			for _, n := range m.Network {
+				samples = append(samples, sample{n.Interface, n.ErrorRate(), t.ForInterface(n.Interface).Errors})
+			}
+			return samples
+		}},
+		{config.MetricNetDrops, func(m *models.Metrics) []sample {
+			samples := make([]sample, 0, len(m.Network))
+			for _, n := range m.Network {
+				samples = append(samples, sample{n.Interface, n.DropRate(), t.ForInterface(n.Interface).Drops})
+			}
+			return samples
+		}},
+
+		pressureRule(config.MetricPressureCPU, func(p models.PressureStats) float64 { return p.CPU.Some.Avg10 }, &t.PressureCPU),
+		pressureRule(config.MetricPressureMemory, func(p models.PressureStats) float64 { return p.Memory.Some.Avg10 }, &t.PressureMemory),
+		pressureRule(config.MetricPressureIO, func(p models.PressureStats) float64 { return p.IO.Some.Avg10 }, &t.PressureIO),
+
+		{config.MetricTemperature, func(m *models.Metrics) []sample {
+			samples := make([]sample, 0, len(m.Sensors.Temperatures))
+			for _, s := range m.Sensors.Temperatures {
+				// Overrides are keyed by name, but alerts need the unique ID
+				samples = append(samples, sample{s.ID(), s.Current, t.SensorCritical(s.Name(), s.Critical)})
+			}
+			return samples
+		}},
+	}
+}
+
+// pressureRule checks a "some" avg10 pressure value when PSI is supported
+func pressureRule(metric string, value func(p models.PressureStats) float64, threshold *float64) rule {
+	return rule{metric, func(m *models.Metrics) []sample {
+		if !m.Pressure.Available {
+			return nil
+		}
+		return []sample{{value: value(m.Pressure), threshold: *threshold}}
+	}}
+}
+
+// Reading is the current value of one metric instance and the threshold
+// it is compared against
+type Reading struct {
+	Metric    string
+	Instance  string // Empty for host-wide metrics
+	Value     float64
+	Threshold float64
+}
+
+// Readings returns every value the thresholds apply to in a snapshot, in
+// the order alerts are evaluated
+func Readings(thresholds *config.Thresholds, metrics *models.Metrics) []Reading {
+	var readings []Reading
+	for _, r := range thresholdRules(thresholds) {
+		for _, s := range r.samples(metrics) {
+			readings = append(readings, Reading{r.metric, s.instance, s.value, s.threshold})
+		}
+	}
+	return readings
+}
@@ -0,0 +1,289 @@
+// Package check evaluates the thresholds against a single snapshot for
+// monitoring plugins such as Nagios and Icinga.
+package check
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// Status is a plugin result, with the value of its exit code
+type Status int
+
+// Plugin results
+const (
+	OK       Status = 0
+	Warning  Status = 1
+	Critical Status = 2
+	Unknown  Status = 3
+)
+
+// String returns the status as printed in plugin output
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// severity orders statuses when combining them: critical, then warning,
+// then unknown, then ok
+func (s Status) severity() int {
+	switch s {
+	case Critical:
+		return 3
+	case Warning:
+		return 2
+	case Unknown:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Subsystems lists the subsystems that can be checked, in report order.
+// The names match the collector's subsystem names.
+var Subsystems = []string{"cpu", "memory", "disk", "network", "kernel", "pressure", "sensors"}
+
+// subsystemMetrics maps each subsystem to the metrics it covers
+var subsystemMetrics = map[string][]string{
+	"cpu":      {config.MetricCPU, config.MetricIOWait, config.MetricSteal},
+	"memory":   {config.MetricMemory, config.MetricSwap},
+	"disk":     {config.MetricDisk, config.MetricInodes},
+	"network":  {config.MetricNetErrors, config.MetricNetDrops},
+	"kernel":   {config.MetricFileDescriptors, config.MetricPIDs},
+	"pressure": {config.MetricPressureCPU, config.MetricPressureMemory, config.MetricPressureIO},
+	"sensors":  {config.MetricTemperature},
+}
+
+// optionalSubsystems may have nothing to check on a healthy host: pressure
+// on kernels without PSI and sensors on virtual machines
+var optionalSubsystems = map[string]bool{
+	"pressure": true,
+	"sensors":  true,
+}
+
+// unitlessMetrics are not percentages: network rates are per second and
+// temperatures are in degrees Celsius, neither of which has a perfdata unit
+var unitlessMetrics = map[string]bool{
+	config.MetricNetErrors:   true,
+	config.MetricNetDrops:    true,
+	config.MetricTemperature: true,
+}
+
+// Result is the outcome of checking one metric instance
+type Result struct {
+	Metric   string
+	Instance string // Empty for host-wide metrics
+	Status   Status
+	Value    float64
+	Warning  float64
+	Critical float64
+}
+
+// Name identifies the result, e.g. "disk /" or "cpu"
+func (r Result) Name() string {
+	if r.Instance == "" {
+		return r.Metric
+	}
+	return r.Metric + " " + r.Instance
+}
+
+// Report holds the results of one check
+type Report struct {
+	Results []Result
+	Unknown []string // Why selected subsystems could not be checked
+}
+
+// Status returns the most severe status in the report. A report without
+// any results is unknown.
+func (r *Report) Status() Status {
+	status := OK
+	if len(r.Unknown) > 0 || len(r.Results) == 0 {
+		status = Unknown
+	}
+	for _, result := range r.Results {
+		if result.Status.severity() > status.severity() {
+			status = result.Status
+		}
+	}
+	return status
+}
+
+// String formats the report as a plugin output line: the status, a
+// summary of the problems and the performance data
+func (r *Report) String() string {
+	status := r.Status()
+
+	var problems []string
+	for _, want := range []Status{Critical, Warning} {
+		for _, result := range r.Results {
+			if result.Status == want {
+				problems = append(problems, describe(result))
+			}
+		}
+	}
+	problems = append(problems, r.Unknown...)
+
+	var summary string
+	switch {
+	case len(problems) > 0:
+		summary = strings.Join(problems, ", ")
+	case len(r.Results) == 0:
+		summary = "no metrics to check"
+	default:
+		summary = fmt.Sprintf("%d values within thresholds", len(r.Results))
+	}
+
+	line := "SYSMON " + status.String() + " - " + summary
+	if len(r.Results) > 0 {
+		perfdata := make([]string, len(r.Results))
+		for i, result := range r.Results {
+			perfdata[i] = formatPerfdata(result)
+		}
+		line += " | " + strings.Join(perfdata, " ")
+	}
+	return line
+}
+
+// Checker evaluates the thresholds of selected subsystems
+type Checker struct {
+	thresholds *config.Thresholds
+	levels     *config.Check
+	subsystems []string
+	explicit   bool // Subsystems were selected rather than defaulted
+}
+
+// NewChecker creates a checker for the named subsystems, or for every
+// subsystem if none are named. thresholds are the critical levels.
+func NewChecker(thresholds *config.Thresholds, levels *config.Check, subsystems []string) (*Checker, error) {
+	for _, name := range subsystems {
+		if _, ok := subsystemMetrics[name]; !ok {
+			return nil, fmt.Errorf("unknown subsystem %q (valid: %s)", name, strings.Join(Subsystems, ", "))
+		}
+	}
+
+	c := &Checker{thresholds: thresholds, levels: levels, explicit: len(subsystems) > 0}
+	for _, name := range Subsystems {
+		if !c.explicit || contains(subsystems, name) {
+			c.subsystems = append(c.subsystems, name)
+		}
+	}
+	return c, nil
+}
+
+// Evaluate checks a snapshot. failed names the subsystems whose collection
+// failed for it, which are reported as unknown so that a broken check
+// never passes. Subsystems with nothing to check are unknown too, except
+// optional ones when no subsystems were selected.
+func (c *Checker) Evaluate(metrics *models.Metrics, failed map[string]bool) *Report {
+	readings := make(map[string][]alert.Reading)
+	for _, reading := range alert.Readings(c.thresholds, metrics) {
+		readings[reading.Metric] = append(readings[reading.Metric], reading)
+	}
+
+	report := &Report{}
+	for _, subsystem := range c.subsystems {
+		if failed[subsystem] {
+			report.Unknown = append(report.Unknown, subsystem+" collection failed")
+			continue
+		}
+
+		var results []Result
+		for _, metric := range subsystemMetrics[subsystem] {
+			for _, reading := range readings[metric] {
+				results = append(results, c.evaluate(reading))
+			}
+		}
+		if len(results) == 0 && (c.explicit || !optionalSubsystems[subsystem]) {
+			report.Unknown = append(report.Unknown, subsystem+" has no data")
+		}
+		report.Results = append(report.Results, results...)
+	}
+	return report
+}
+
+// evaluate compares one reading against its warning and critical levels.
+// Like alerts, a level is crossed when the value exceeds it.
+func (c *Checker) evaluate(reading alert.Reading) Result {
+	result := Result{
+		Metric:   reading.Metric,
+		Instance: reading.Instance,
+		Value:    reading.Value,
+		Warning:  c.levels.WarningLevel(reading.Metric, reading.Threshold),
+		Critical: reading.Threshold,
+	}
+	switch {
+	case result.Value > result.Critical:
+		result.Status = Critical
+	case result.Value > result.Warning:
+		result.Status = Warning
+	default:
+		result.Status = OK
+	}
+	return result
+}
+
+// describe summarises a failed result, e.g. "disk / 95.20% > 90"
+func describe(r Result) string {
+	level := r.Critical
+	if r.Status == Warning {
+		level = r.Warning
+	}
+	return fmt.Sprintf("%s %s%s > %s", r.Name(), strconv.FormatFloat(r.Value, 'f', 2, 64), unit(r.Metric), formatNumber(level))
+}
+
+// formatPerfdata formats a result as performance data:
+// 'label'=value[unit];warn;crit;min[;max]
+func formatPerfdata(r Result) string {
+	perfdata := fmt.Sprintf("%s=%s%s;%s;%s;0", perfLabel(r.Name()), formatNumber(r.Value), unit(r.Metric),
+		formatNumber(r.Warning), formatNumber(r.Critical))
+	if unit(r.Metric) == "%" {
+		perfdata += ";100"
+	}
+	return perfdata
+}
+
+// perfLabel quotes a performance data label if it contains spaces,
+// equals signs or quotes
+func perfLabel(label string) string {
+	if !strings.ContainsAny(label, " ='") {
+		return label
+	}
+	return "'" + strings.ReplaceAll(label, "'", "''") + "'"
+}
+
+// unit returns the performance data unit of a metric
+func unit(metric string) string {
+	if unitlessMetrics[metric] {
+		return ""
+	}
+	return "%"
+}
+
+// formatNumber formats a value rounded to two decimal places without
+// trailing zeros
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// snapshot returns metrics with the given CPU, memory and root disk usage
+func snapshot(cpu, memory, disk float64) *models.Metrics {
+	return &models.Metrics{
+		CPU:    models.CPUStats{Overall: cpu},
+		Memory: models.MemoryStats{Percent: memory},
+		Disk:   []models.DiskStats{{Mountpoint: "/", Percent: disk}},
+	}
+}
+
+func TestCheckerStatus(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Thresholds.CPU = 80
+	cfg.Thresholds.Memory = 80
+	cfg.Thresholds.Disk = 90
+	cfg.Check.Warning = map[string]float64{config.MetricDisk: 70}
+
+	tests := []struct {
+		name    string
+		metrics *models.Metrics
+		want    Status
+	}{
+		{"all below warning", snapshot(10, 20, 30), OK},
+		{"at warning level", snapshot(72, 20, 30), OK},
+		{"above ratio warning", snapshot(75, 20, 30), Warning},
+		{"above configured warning", snapshot(10, 20, 75), Warning},
+		{"above critical", snapshot(10, 85, 30), Critical},
+		{"critical outranks warning", snapshot(75, 20, 95), Critical},
+	}
+
+	checker, err := NewChecker(&cfg.Thresholds, &cfg.Check, []string{"cpu", "memory", "disk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		report := checker.Evaluate(tt.metrics, nil)
+		if got := report.Status(); got != tt.want {
+			t.Errorf("%s: status = %v, want %v (%s)", tt.name, got, tt.want, report)
+		}
+	}
+}
+
+func TestCheckerSelection(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+
+	if _, err := NewChecker(&cfg.Thresholds, &cfg.Check, []string{"gpu"}); err == nil {
+		t.Error("NewChecker accepted an unknown subsystem")
+	}
+
+	// Only the selected subsystem is checked
+	checker, _ := NewChecker(&cfg.Thresholds, &cfg.Check, []string{"disk"})
+	report := checker.Evaluate(snapshot(99, 99, 10), nil)
+	if got := report.Status(); got != OK {
+		t.Errorf("disk only: status = %v, want OK (%s)", got, report)
+	}
+
+	// A selected subsystem without data or whose collection failed is unknown
+	checker, _ = NewChecker(&cfg.Thresholds, &cfg.Check, []string{"memory", "pressure"})
+	report = checker.Evaluate(snapshot(10, 10, 10), nil)
+	if got := report.Status(); got != Unknown {
+		t.Errorf("pressure without data: status = %v, want UNKNOWN (%s)", got, report)
+	}
+	report = checker.Evaluate(snapshot(10, 10, 10), map[string]bool{"memory": true})
+	if !strings.Contains(report.String(), "memory collection failed") {
+		t.Errorf("failed collection not reported: %s", report)
+	}
+
+	// By default optional subsystems without data are skipped, but failed
+	// collections and other subsystems without data are unknown
+	checker, _ = NewChecker(&cfg.Thresholds, &cfg.Check, nil)
+	full := snapshot(10, 10, 10)
+	full.Network = []models.NetworkStats{{Interface: "eth0"}}
+	full.Kernel = models.KernelTableStats{FilesMax: 1000, PIDMax: 1000}
+	if report := checker.Evaluate(full, nil); report.Status() != OK {
+		t.Errorf("default selection: status = %v, want OK (%s)", report.Status(), report)
+	}
+	report = checker.Evaluate(full, map[string]bool{"cpu": true})
+	if got := report.Status(); got != Unknown || !strings.Contains(report.String(), "cpu collection failed") {
+		t.Errorf("default selection with failed cpu: status = %v, want UNKNOWN (%s)", got, report)
+	}
+	report = checker.Evaluate(snapshot(10, 10, 10), nil)
+	if got := report.Status(); got != Unknown || !strings.Contains(report.String(), "network has no data") {
+		t.Errorf("default selection without network: status = %v, want UNKNOWN (%s)", got, report)
+	}
+}
+
+func TestReportString(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Thresholds.CPU = 80
+	cfg.Thresholds.Memory = 85
+	cfg.Thresholds.Disk = 90
+
+	metrics := snapshot(12.345, 80, 95.2)
+	metrics.Disk = append(metrics.Disk, models.DiskStats{Mountpoint: "/mnt/it's here", Percent: 5})
+	metrics.Network = []models.NetworkStats{{Interface: "eth0"}}
+
+	checker, _ := NewChecker(&cfg.Thresholds, &cfg.Check, []string{"cpu", "memory", "disk", "network"})
+	got := checker.Evaluate(metrics, nil).String()
+
+	want := "SYSMON CRITICAL - disk / 95.20% > 90, memory 80.00% > 76.5 | " +
+		"cpu=12.35%;72;80;0;100 iowait=0%;18;20;0;100 steal=0%;9;10;0;100 " +
+		"memory=80%;76.5;85;0;100 swap=0%;45;50;0;100 " +
+		"'disk /'=95.2%;81;90;0;100 'disk /mnt/it''s here'=5%;81;90;0;100 " +
+		"'netErrors eth0'=0;0.9;1;0 'netDrops eth0'=0;9;10;0"
+	if got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+
+	empty := &Report{}
+	if got := empty.String(); got != "SYSMON UNKNOWN - no metrics to check" {
+		t.Errorf("empty report = %q", got)
+	}
+}
+
+func TestReportSensorsWithSameName(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+
+	metrics := snapshot(10, 10, 10)
+	metrics.Sensors.Temperatures = []models.TemperatureSensor{
+		{Chip: "nvme", Device: "hwmon1", Label: "Composite", Current: 40, Critical: 80},
+		{Chip: "nvme", Device: "hwmon2", Label: "Composite", Current: 85, Critical: 80},
+	}
+
+	checker, _ := NewChecker(&cfg.Thresholds, &cfg.Check, []string{"sensors"})
+	got := checker.Evaluate(metrics, nil).String()
+
+	// Each drive has its own performance data label
+	want := "SYSMON CRITICAL - temperature nvme/Composite (hwmon2) 85.00 > 80 | " +
+		"'temperature nvme/Composite (hwmon1)'=40;72;80;0 'temperature nvme/Composite (hwmon2)'=85;72;80;0"
+	if got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
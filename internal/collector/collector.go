@@ -3,8 +3,12 @@ package collector
 import (
 	"context"
 	"log"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/counter"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 )
 
@@ -13,16 +17,79 @@ type Collector struct {
 	provider SystemStatsProvider
 	prevNet  []models.NetworkStats
 	prevTime time.Time
+
+	prevDiskIO     []models.DiskIOStats
+	prevDiskIOTime time.Time
+
+	cgroupLimits     bool
+	prevCgroupUsage  time.Duration
+	prevCgroupTime   time.Time
+	prevCgroupActive bool
+
+	prevActivity     *models.ActivityStats
+	prevActivityTime time.Time
+
+	containers ContainerStatsProvider
+
+	topProcesses  int
+	processSort   string
+	prevProcs     map[int]time.Duration // Previous CPU time by PID
+	prevProcsTime time.Time
+
+	statsMu sync.Mutex
+	stats   CollectionStats
+}
+
+// CollectionStats counts the collector's own work, for self-monitoring
+type CollectionStats struct {
+	Collections   uint64            // Completed Collect calls
+	TotalDuration time.Duration     // Time spent in all Collect calls
+	LastDuration  time.Duration     // Time spent in the most recent Collect call
+	Errors        map[string]uint64 // Failed subsystem collections by subsystem name
 }
 
 // NewCollector creates a new metrics collector with the given provider
 func NewCollector(provider SystemStatsProvider) *Collector {
+	now := time.Now()
 	return &Collector{
-		provider: provider,
-		prevTime: time.Now(),
+		provider:       provider,
+		prevTime:       now,
+		prevDiskIOTime: now,
+		prevProcsTime:  now,
 	}
 }
 
+// SetCgroupLimits controls whether the headline CPU and memory
+// percentages are computed against the cgroup limits instead of the host
+// capacity. It only takes effect when the cgroup has a limit set.
+func (c *Collector) SetCgroupLimits(enabled bool) {
+	c.cgroupLimits = enabled
+}
+
+// containerTimeout bounds each container stats query, so an unresponsive
+// container runtime cannot stall the other collectors
+const containerTimeout = 3 * time.Second
+
+// SetContainerProvider enables collection of per-container statistics
+// from the given container runtime. A nil provider disables it.
+func (c *Collector) SetContainerProvider(provider ContainerStatsProvider) {
+	c.containers = provider
+}
+
+// SetProcessSort changes the process table sort order. It must not be
+// called while Start is running.
+func (c *Collector) SetProcessSort(sortBy string) {
+	c.processSort = sortBy
+}
+
+// SetProcessOptions configures the process table: the number of processes
+// to keep and whether to rank them by CPU or memory. A limit of zero
+// disables process collection.
+func (c *Collector) SetProcessOptions(top int, sortBy string) {
+	c.topProcesses = top
+	c.processSort = sortBy
+}
+
 // Collect gathers a single snapshot of system metrics
 // It implements partial failure handling - errors in individual subsystems
 // don't prevent collection of other metrics
@@ -30,31 +97,51 @@ func (c *Collector) Collect(ctx context.Context) (*models.Metrics, error) {
 	metrics := &models.Metrics{
 		Timestamp: time.Now(),
 	}
+	defer c.collectionDone(metrics.Timestamp)
+
+	// Collect host summary
+	if host, err := c.provider.GetHostStats(); err != nil {
+		c.collectionFailed("host", "Host", err)
+	} else {
+		metrics.Host = *host
+	}
 
 	// Collect CPU stats
 	if cpu, err := c.provider.GetCPUStats(); err != nil {
-		log.Printf("Warning: CPU collection failed: %v", err)
+		c.collectionFailed("cpu", "CPU", err)
 	} else {
 		metrics.CPU = *cpu
 	}
 
 	// Collect memory stats
 	if mem, err := c.provider.GetMemoryStats(); err != nil {
-		log.Printf("Warning: Memory collection failed: %v", err)
+		c.collectionFailed("memory", "Memory", err)
 	} else {
 		metrics.Memory = *mem
 	}
 
 	// Collect disk stats
 	if disk, err := c.provider.GetDiskStats(); err != nil {
-		log.Printf("Warning: Disk collection failed: %v", err)
+		c.collectionFailed("disk", "Disk", err)
 	} else {
 		metrics.Disk = disk
 	}
 
+	// Collect disk I/O stats and calculate rates
+	if diskIO, err := c.provider.GetDiskIOStats(); err != nil {
+		c.collectionFailed("diskio", "Disk I/O", err)
+	} else {
+		if c.prevDiskIO != nil {
+			diskIO = c.calculateDiskIORates(diskIO)
+		}
+		metrics.DiskIO = diskIO
+		c.prevDiskIO = diskIO
+		c.prevDiskIOTime = metrics.Timestamp
+	}
+
 	// Collect network stats and calculate rates
 	if net, err := c.provider.GetNetworkStats(); err != nil {
-		log.Printf("Warning: Network collection failed: %v", err)
+		c.collectionFailed("network", "Network", err)
 	} else {
 		// Calculate rates if we have previous data
 		if c.prevNet != nil {
@@ -65,9 +152,112 @@ func (c *Collector) Collect(ctx context.Context) (*models.Metrics, error) {
 		c.prevTime = metrics.Timestamp
 	}
 
+	// Collect pressure stall information
+	if pressure, err := c.provider.GetPressureStats(); err != nil {
+		c.collectionFailed("pressure", "Pressure", err)
+	} else {
+		metrics.Pressure = *pressure
+	}
+
+	// Collect cgroup accounting
+	if cgroup, err := c.provider.GetCgroupStats(); err != nil {
+		c.collectionFailed("cgroup", "Cgroup", err)
+	} else {
+		metrics.Cgroup = c.calculateCgroupUsage(*cgroup, len(metrics.CPU.PerCore), metrics.Timestamp)
+		if c.cgroupLimits {
+			applyCgroupLimits(metrics)
+		}
+	}
+
+	// Collect hardware sensors
+	if sensors, err := c.provider.GetSensorStats(); err != nil {
+		c.collectionFailed("sensors", "Sensor", err)
+	} else {
+		metrics.Sensors = *sensors
+	}
+
+	// Collect socket summary
+	if sockets, err := c.provider.GetSocketStats(); err != nil {
+		c.collectionFailed("sockets", "Socket", err)
+	} else {
+		metrics.Sockets = *sockets
+	}
+
+	// Collect kernel activity counters and calculate rates
+	if activity, err := c.provider.GetActivityStats(); err != nil {
+		c.collectionFailed("activity", "Activity", err)
+	} else {
+		metrics.Activity = c.calculateActivityRates(*activity, metrics.Timestamp)
+	}
+
+	// Collect kernel table usage
+	if kernel, err := c.provider.GetKernelTableStats(); err != nil {
+		c.collectionFailed("kernel", "Kernel table", err)
+	} else {
+		metrics.Kernel = *kernel
+	}
+
+	// Collect container stats
+	if c.containers != nil {
+		containerCtx, cancel := context.WithTimeout(ctx, containerTimeout)
+		if containers, err := c.containers.GetContainerStats(containerCtx); err != nil {
+			c.collectionFailed("containers", "Container", err)
+		} else {
+			metrics.Containers = containers
+		}
+		cancel()
+	}
+
+	// Collect process stats and keep the top N
+	if c.topProcesses > 0 {
+		if procs, err := c.provider.GetProcessStats(); err != nil {
+			c.collectionFailed("processes", "Process", err)
+		} else {
+			procs = c.calculateProcessUsage(procs, metrics.Memory.Total, metrics.Timestamp)
+			metrics.Processes = c.topN(procs)
+		}
+	}
+
 	return metrics, nil
 }
 
+// Stats returns a copy of the collector's self-monitoring counters. It is
+// safe to call while Start is running.
+func (c *Collector) Stats() CollectionStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	stats := c.stats
+	stats.Errors = make(map[string]uint64, len(c.stats.Errors))
+	for subsystem, n := range c.stats.Errors {
+		stats.Errors[subsystem] = n
+	}
+	return stats
+}
+
+// collectionFailed logs a failed subsystem collection and counts it
+func (c *Collector) collectionFailed(subsystem, name string, err error) {
+	log.Printf("Warning: %s collection failed: %v", name, err)
+
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	if c.stats.Errors == nil {
+		c.stats.Errors = make(map[string]uint64)
+	}
+	c.stats.Errors[subsystem]++
+}
+
+// collectionDone records the duration of a Collect call started at start
+func (c *Collector) collectionDone(start time.Time) {
+	elapsed := time.Since(start)
+
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	c.stats.Collections++
+	c.stats.TotalDuration += elapsed
+	c.stats.LastDuration = elapsed
+}
+
 // Start begins periodic metric collection, sending results to the output channel
 // It runs in a goroutine and respects context cancellation
 func (c *Collector) Start(ctx context.Context, interval time.Duration, out chan<- *models.Metrics) error {
@@ -141,8 +331,202 @@ func (c *Collector) calculateNetworkRates(current []models.NetworkStats) []model
 			if result[i].RecvRate < 0 {
 				result[i].RecvRate = 0
 			}
+
+			// Calculate packet, error and drop rates
+			result[i].PacketRecvRate = float64(counter.Delta(prev.PacketsRecv, curr.PacketsRecv)) / timeDelta
+			result[i].PacketSendRate = float64(counter.Delta(prev.PacketsSent, curr.PacketsSent)) / timeDelta
+			result[i].ErrorRecvRate = float64(counter.Delta(prev.ErrorsRecv, curr.ErrorsRecv)) / timeDelta
+			result[i].ErrorSendRate = float64(counter.Delta(prev.ErrorsSent, curr.ErrorsSent)) / timeDelta
+			result[i].DropRecvRate = float64(counter.Delta(prev.DropsRecv, curr.DropsRecv)) / timeDelta
+			result[i].DropSendRate = float64(counter.Delta(prev.DropsSent, curr.DropsSent)) / timeDelta
+
+			// Calculate link fault rates
+			fifoDelta := counter.Delta(prev.FifoRecv, curr.FifoRecv) + counter.Delta(prev.FifoSent, curr.FifoSent)
+			result[i].FifoRate = float64(fifoDelta) / timeDelta
+			result[i].FrameRate = float64(counter.Delta(prev.FrameRecv, curr.FrameRecv)) / timeDelta
+			result[i].CarrierRate = float64(counter.Delta(prev.CarrierSent, curr.CarrierSent)) / timeDelta
+		}
+	}
+
+	return result
+}
+
+// calculateDiskIORates computes throughput, IOPS, await and utilization
+// based on previous measurements
+func (c *Collector) calculateDiskIORates(current []models.DiskIOStats) []models.DiskIOStats {
+	if len(c.prevDiskIO) == 0 {
+		return current
+	}
+
+	timeDelta := time.Since(c.prevDiskIOTime).Seconds()
+	if timeDelta == 0 {
+		return current
+	}
+
+	// Create a map of previous stats by device name for quick lookup
+	prevMap := make(map[string]models.DiskIOStats)
+	for _, prev := range c.prevDiskIO {
+		prevMap[prev.Device] = prev
+	}
+
+	// Calculate rates for each device
+	result := make([]models.DiskIOStats, len(current))
+	for i, curr := range current {
+		result[i] = curr
+
+		prev, exists := prevMap[curr.Device]
+		if !exists {
+			continue
+		}
+
+		reads := counter.Delta(prev.ReadsCompleted, curr.ReadsCompleted)
+		writes := counter.Delta(prev.WritesCompleted, curr.WritesCompleted)
+		ioTime := counter.Delta(prev.ReadTimeMs, curr.ReadTimeMs) + counter.Delta(prev.WriteTimeMs, curr.WriteTimeMs)
+
+		result[i].ReadRate = float64(counter.Delta(prev.ReadBytes, curr.ReadBytes)) / timeDelta
+		result[i].WriteRate = float64(counter.Delta(prev.WriteBytes, curr.WriteBytes)) / timeDelta
+		result[i].ReadIOPS = float64(reads) / timeDelta
+		result[i].WriteIOPS = float64(writes) / timeDelta
+
+		if reads+writes > 0 {
+			result[i].AwaitMs = float64(ioTime) / float64(reads+writes)
+		}
+
+		// IOTimeMs advances by at most 1000ms per second of wall time
+		result[i].Util = float64(counter.Delta(prev.IOTimeMs, curr.IOTimeMs)) / (timeDelta * 1000.0) * 100.0
+		if result[i].Util > 100.0 {
+			result[i].Util = 100.0
 		}
 	}
 
 	return result
 }
+
+// calculateCgroupUsage computes cgroup CPU usage since the previous sample,
+// relative to the CPU limit or to all host cores when unlimited
+func (c *Collector) calculateCgroupUsage(current models.CgroupStats, cores int, now time.Time) models.CgroupStats {
+	if !current.Available {
+		c.prevCgroupActive = false
+		return current
+	}
+
+	capacity := current.CPULimit
+	if capacity == 0 {
+		capacity = float64(cores)
+	}
+
+	timeDelta := now.Sub(c.prevCgroupTime).Seconds()
+	if c.prevCgroupActive && timeDelta > 0 && capacity > 0 && current.CPUUsage >= c.prevCgroupUsage {
+		used := (current.CPUUsage - c.prevCgroupUsage).Seconds()
+		current.CPUPercent = used / (timeDelta * capacity) * 100.0
+	}
+
+	c.prevCgroupUsage = current.CPUUsage
+	c.prevCgroupTime = now
+	c.prevCgroupActive = true
+	return current
+}
+
+// applyCgroupLimits replaces the headline CPU and memory figures with
+// values relative to the cgroup limits, where limits are set
+func applyCgroupLimits(metrics *models.Metrics) {
+	cg := metrics.Cgroup
+	if !cg.Available {
+		return
+	}
+
+	if cg.CPULimit > 0 {
+		metrics.CPU.Overall = cg.CPUPercent
+	}
+
+	if cg.MemoryMax > 0 {
+		available := uint64(0)
+		if cg.MemoryMax > cg.MemoryCurrent {
+			available = cg.MemoryMax - cg.MemoryCurrent
+		}
+		metrics.Memory.Total = cg.MemoryMax
+		metrics.Memory.Used = cg.MemoryCurrent
+		metrics.Memory.Available = available
+		metrics.Memory.Percent = cg.MemoryPercent
+	}
+}
+
+// calculateActivityRates computes per-second kernel activity rates and the
+// number of OOM kills since the previous sample
+func (c *Collector) calculateActivityRates(current models.ActivityStats, now time.Time) models.ActivityStats {
+	prev := c.prevActivity
+	timeDelta := now.Sub(c.prevActivityTime).Seconds()
+
+	snapshot := current
+	c.prevActivity = &snapshot
+	c.prevActivityTime = now
+
+	if prev == nil || timeDelta <= 0 {
+		return current
+	}
+
+	rate := func(prev, curr uint64) float64 {
+		return float64(counter.Delta(prev, curr)) / timeDelta
+	}
+
+	current.ContextSwitchRate = rate(prev.ContextSwitches, current.ContextSwitches)
+	current.InterruptRate = rate(prev.Interrupts, current.Interrupts)
+	current.ForkRate = rate(prev.Forks, current.Forks)
+	current.PageFaultRate = rate(prev.PageFaults, current.PageFaults)
+	current.MajorPageFaultRate = rate(prev.MajorPageFaults, current.MajorPageFaults)
+	current.PageInRate = rate(prev.PagedIn, current.PagedIn)
+	current.PageOutRate = rate(prev.PagedOut, current.PagedOut)
+	current.SwapInRate = rate(prev.SwappedIn, current.SwappedIn)
+	current.SwapOutRate = rate(prev.SwappedOut, current.SwappedOut)
+	current.NewOOMKills = counter.Delta(prev.OOMKills, current.OOMKills)
+
+	return current
+}
+
+// calculateProcessUsage computes per-process CPU usage since the previous
+// sample and memory usage relative to total memory
+func (c *Collector) calculateProcessUsage(current []models.ProcessStats, memTotal uint64, now time.Time) []models.ProcessStats {
+	timeDelta := now.Sub(c.prevProcsTime).Seconds()
+	cpuTimes := make(map[int]time.Duration, len(current))
+
+	for i := range current {
+		proc := &current[i]
+		cpuTimes[proc.PID] = proc.CPUTime
+
+		if memTotal > 0 {
+			proc.MemPercent = models.CalculatePercentage(proc.RSS, memTotal)
+		}
+
+		// A PID that is new, or whose CPU time went backwards (PID reuse),
+		// has no baseline yet
+		prev, exists := c.prevProcs[proc.PID]
+		if !exists || timeDelta <= 0 || proc.CPUTime < prev {
+			continue
+		}
+		proc.CPUPercent = (proc.CPUTime - prev).Seconds() / timeDelta * 100.0
+	}
+
+	c.prevProcs = cpuTimes
+	c.prevProcsTime = now
+	return current
+}
+
+// topN sorts processes by the configured order and keeps the first N
+func (c *Collector) topN(procs []models.ProcessStats) []models.ProcessStats {
+	sort.Slice(procs, func(i, j int) bool {
+		a, b := procs[i], procs[j]
+		if c.processSort == config.ProcessSortMemory {
+			if a.RSS != b.RSS {
+				return a.RSS > b.RSS
+			}
+		} else if a.CPUPercent != b.CPUPercent {
+			return a.CPUPercent > b.CPUPercent
+		}
+		return a.PID < b.PID
+	})
+
+	if len(procs) > c.topProcesses {
+		procs = procs[:c.topProcesses]
+	}
+	return procs
+}
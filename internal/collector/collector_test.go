@@ -0,0 +1,214 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// approx reports whether got is within 1% of want, allowing for the time
+// that passes while a test runs
+func approx(got, want float64) bool {
+	return math.Abs(got-want) <= math.Abs(want)*0.01
+}
+
+func TestCalculateNetworkRates(t *testing.T) {
+	c := NewCollector(nil)
+	c.prevNet = []models.NetworkStats{{
+		Interface:   "eth0",
+		ErrorsRecv:  10,
+		FifoRecv:    4,
+		FifoSent:    2,
+		FrameRecv:   6,
+		CarrierSent: 1,
+	}}
+	c.prevTime = time.Now().Add(-2 * time.Second)
+
+	rates := c.calculateNetworkRates([]models.NetworkStats{{
+		Interface:   "eth0",
+		ErrorsRecv:  30,
+		FifoRecv:    8,
+		FifoSent:    6,
+		FrameRecv:   16,
+		CarrierSent: 5,
+	}})
+
+	net := rates[0]
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"ErrorRecvRate", net.ErrorRecvRate, 10},
+		{"FifoRate", net.FifoRate, 4},
+		{"FrameRate", net.FrameRate, 5},
+		{"CarrierRate", net.CarrierRate, 2},
+	}
+	for _, tt := range tests {
+		if !approx(tt.got, tt.want) {
+			t.Errorf("%s = %.2f, want %.2f", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// stubProvider serves fixed CPU, memory and cgroup stats; the other
+// subsystems fail
+type stubProvider struct {
+	cpu    models.CPUStats
+	memory models.MemoryStats
+	cgroup models.CgroupStats
+}
+
+var errStub = errors.New("not provided by stub")
+
+func (p *stubProvider) GetCPUStats() (*models.CPUStats, error)       { return &p.cpu, nil }
+func (p *stubProvider) GetMemoryStats() (*models.MemoryStats, error) { return &p.memory, nil }
+func (p *stubProvider) GetCgroupStats() (*models.CgroupStats, error) { return &p.cgroup, nil }
+
+func (p *stubProvider) GetHostStats() (*models.HostStats, error)               { return nil, errStub }
+func (p *stubProvider) GetDiskStats() ([]models.DiskStats, error)              { return nil, errStub }
+func (p *stubProvider) GetDiskIOStats() ([]models.DiskIOStats, error)          { return nil, errStub }
+func (p *stubProvider) GetNetworkStats() ([]models.NetworkStats, error)        { return nil, errStub }
+func (p *stubProvider) GetPressureStats() (*models.PressureStats, error)       { return nil, errStub }
+func (p *stubProvider) GetSensorStats() (*models.SensorStats, error)           { return nil, errStub }
+func (p *stubProvider) GetSocketStats() (*models.SocketStats, error)           { return nil, errStub }
+func (p *stubProvider) GetActivityStats() (*models.ActivityStats, error)       { return nil, errStub }
+func (p *stubProvider) GetKernelTableStats() (*models.KernelTableStats, error) { return nil, errStub }
+func (p *stubProvider) GetProcessStats() ([]models.ProcessStats, error)        { return nil, errStub }
+
+func TestCalculateCgroupUsage(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name    string
+		limit   float64 // CPU limit in cores, 0 for unlimited
+		usage   time.Duration
+		elapsed time.Duration
+		want    float64
+	}{
+		{"half of a two core limit", 2, time.Second, time.Second, 50},
+		{"unlimited uses all cores", 0, 2 * time.Second, time.Second, 50},
+		{"fractional limit", 0.5, 250 * time.Millisecond, time.Second, 50},
+		{"usage going backwards", 2, -time.Second, time.Second, 0},
+		{"no time elapsed", 2, time.Second, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector(&stubProvider{})
+			first := models.CgroupStats{Available: true, CPULimit: tt.limit, CPUUsage: 10 * time.Second}
+			if got := c.calculateCgroupUsage(first, 4, start); got.CPUPercent != 0 {
+				t.Errorf("first sample CPUPercent = %.2f, want 0", got.CPUPercent)
+			}
+
+			second := first
+			second.CPUUsage += tt.usage
+			got := c.calculateCgroupUsage(second, 4, start.Add(tt.elapsed))
+			if got.CPUPercent != tt.want {
+				t.Errorf("CPUPercent = %.2f, want %.2f", got.CPUPercent, tt.want)
+			}
+		})
+	}
+
+	// Usage restarts from nothing when the cgroup disappears
+	c := NewCollector(&stubProvider{})
+	c.calculateCgroupUsage(models.CgroupStats{Available: true, CPUUsage: time.Second}, 4, start)
+	c.calculateCgroupUsage(models.CgroupStats{}, 4, start.Add(time.Second))
+	got := c.calculateCgroupUsage(models.CgroupStats{Available: true, CPUUsage: 3 * time.Second}, 4, start.Add(2*time.Second))
+	if got.CPUPercent != 0 {
+		t.Errorf("CPUPercent after the cgroup reappeared = %.2f, want 0", got.CPUPercent)
+	}
+}
+
+func TestApplyCgroupLimits(t *testing.T) {
+	host := models.Metrics{
+		CPU:    models.CPUStats{Overall: 10},
+		Memory: models.MemoryStats{Total: 16000, Used: 4000, Available: 12000, Percent: 25},
+	}
+
+	tests := []struct {
+		name       string
+		cgroup     models.CgroupStats
+		wantCPU    float64
+		wantMemory models.MemoryStats
+	}{
+		{
+			name:       "no cgroup",
+			cgroup:     models.CgroupStats{CPULimit: 2, CPUPercent: 80, MemoryMax: 1000},
+			wantCPU:    10,
+			wantMemory: host.Memory,
+		},
+		{
+			name:       "no limits",
+			cgroup:     models.CgroupStats{Available: true, CPUPercent: 5, MemoryCurrent: 500},
+			wantCPU:    10,
+			wantMemory: host.Memory,
+		},
+		{
+			name: "both limits",
+			cgroup: models.CgroupStats{Available: true, CPULimit: 2, CPUPercent: 80,
+				MemoryCurrent: 600, MemoryMax: 1000, MemoryPercent: 60},
+			wantCPU:    80,
+			wantMemory: models.MemoryStats{Total: 1000, Used: 600, Available: 400, Percent: 60},
+		},
+		{
+			name:       "memory over its limit",
+			cgroup:     models.CgroupStats{Available: true, MemoryCurrent: 1200, MemoryMax: 1000, MemoryPercent: 120},
+			wantCPU:    10,
+			wantMemory: models.MemoryStats{Total: 1000, Used: 1200, Available: 0, Percent: 120},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := host
+			metrics.Cgroup = tt.cgroup
+			applyCgroupLimits(&metrics)
+
+			if metrics.CPU.Overall != tt.wantCPU {
+				t.Errorf("CPU.Overall = %.2f, want %.2f", metrics.CPU.Overall, tt.wantCPU)
+			}
+			if metrics.Memory != tt.wantMemory {
+				t.Errorf("Memory = %+v, want %+v", metrics.Memory, tt.wantMemory)
+			}
+		})
+	}
+}
+
+func TestCollectCgroupLimits(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	provider := &stubProvider{
+		cpu:    models.CPUStats{Overall: 10, PerCore: make([]float64, 4)},
+		memory: models.MemoryStats{Total: 16000, Used: 4000, Percent: 25},
+		cgroup: models.CgroupStats{Available: true, CPULimit: 2, MemoryCurrent: 600, MemoryMax: 1000, MemoryPercent: 60},
+	}
+
+	// The headline figures are swapped for the cgroup's only when enabled
+	for _, enabled := range []bool{false, true} {
+		c := NewCollector(provider)
+		c.SetCgroupLimits(enabled)
+		metrics, err := c.Collect(context.Background())
+		if err != nil {
+			t.Fatalf("Collect() error = %v", err)
+		}
+
+		wantCPU, wantMemory := 10.0, 25.0
+		if enabled {
+			// The first sample has no cgroup CPU usage yet
+			wantCPU, wantMemory = 0, 60
+		}
+		if metrics.CPU.Overall != wantCPU || metrics.Memory.Percent != wantMemory {
+			t.Errorf("limits %v: CPU.Overall/Memory.Percent = %.2f/%.2f, want %.2f/%.2f",
+				enabled, metrics.CPU.Overall, metrics.Memory.Percent, wantCPU, wantMemory)
+		}
+	}
+}
@@ -16,8 +16,23 @@ type MetricsCollector interface {
 	Start(ctx context.Context, interval time.Duration, out chan<- *models.Metrics) error
 }
 
+// StatsReporter is implemented by collectors that count their own work
+type StatsReporter interface {
+	// Stats returns the collector's self-monitoring counters
+	Stats() CollectionStats
+}
+
+// ContainerStatsProvider defines the interface for container runtime statistics
+type ContainerStatsProvider interface {
+	// GetContainerStats retrieves resource usage for all running containers
+	GetContainerStats(ctx context.Context) ([]models.ContainerStats, error)
+}
+
 // SystemStatsProvider defines the interface for OS-specific system statistics
 type SystemStatsProvider interface {
+	// GetHostStats retrieves load average, uptime and task counts
+	GetHostStats() (*models.HostStats, error)
+
 	// GetCPUStats retrieves CPU usage statistics
 	GetCPUStats() (*models.CPUStats, error)
 
@@ -27,6 +42,31 @@ type SystemStatsProvider interface {
 	// GetDiskStats retrieves disk usage statistics for all mounted filesystems
 	GetDiskStats() ([]models.DiskStats, error)
 
+	// GetDiskIOStats retrieves I/O counters for all block devices
+	GetDiskIOStats() ([]models.DiskIOStats, error)
+
 	// GetNetworkStats retrieves network I/O statistics for all interfaces
 	GetNetworkStats() ([]models.NetworkStats, error)
+
+	// GetPressureStats retrieves Pressure Stall Information
+	GetPressureStats() (*models.PressureStats, error)
+
+	// GetCgroupStats retrieves cgroup v2 resource accounting
+	GetCgroupStats() (*models.CgroupStats, error)
+
+	// GetSensorStats retrieves hardware temperature and fan readings
+	GetSensorStats() (*models.SensorStats, error)
+
+	// GetSocketStats retrieves a summary of TCP and UDP sockets
+	GetSocketStats() (*models.SocketStats, error)
+
+	// GetActivityStats retrieves kernel activity counters such as context
+	// switches, page faults and OOM kills
+	GetActivityStats() (*models.ActivityStats, error)
+
+	// GetKernelTableStats retrieves file handle, inode cache and PID usage
+	GetKernelTableStats() (*models.KernelTableStats, error)
+
+	// GetProcessStats retrieves resource usage for all running processes
+	GetProcessStats() ([]models.ProcessStats, error)
 }
@@ -1,21 +1,232 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Config holds all configuration for the system monitor
 type Config struct {
-	Interval   time.Duration // Refresh interval for metrics collection
-	JSONMode   bool          // Enable JSON output mode
-	LogFile    string        // Path to log file (empty if logging disabled)
-	ConfigFile string        // Path to configuration file
-	Thresholds Thresholds    // Alert thresholds
+	Interval    time.Duration // Refresh interval for metrics collection
+	JSONMode    bool          // Enable JSON output mode
+	Interactive bool          // Enable keyboard control of the terminal display
+	History     int           // Number of recent samples graphed per metric (0 disables graphs)
+	LogFile     string        // Path to log file (empty if logging disabled)
+	ConfigFile  string        // Path to configuration file
+	Listen      string        // Address the serve command exposes Prometheus metrics on
+	Thresholds  Thresholds    // Alert thresholds
+	Alerts      Alerts        // How threshold crossings turn into alerts
+	Notify      Notify        // Where alert transitions are sent
+	Check       Check         // Warning levels for the check command
+
+	// Bounded runs. They start with a warm-up sample that is not output,
+	// so rates in the first output cover a full interval.
+	Count    int           // Number of samples to output before exiting, 0 for no limit
+	Duration time.Duration // Time after which to exit, 0 for no limit
+
+	ProcRoot string // Root of the procfs mount (Linux only)
+	SysRoot  string // Root of the sysfs mount (Linux only)
+	HostRoot string // Mount of the host's root filesystem, used for disk usage (Linux only)
+
+	CgroupPath   string // cgroup v2 path to account, empty for sysmon's own cgroup (Linux only)
+	CgroupLimits bool   // Use cgroup limits as denominators for headline CPU and memory percentages
+
+	Docker       bool   // Collect per-container statistics from the Docker Engine API
+	DockerSocket string // Path to the Docker Engine API unix socket, empty for the Docker default
+
+	TopProcesses int    // Number of processes to show (0 disables the process table)
+	ProcessSort  string // Process table sort order: "cpu" or "memory"
+}
+
+// Bounded reports whether the run stops by itself after a number of
+// samples or a duration
+func (c *Config) Bounded() bool {
+	return c.Count > 0 || c.Duration > 0
 }
 
+// MaxHistory is the largest history window, in samples. Sparklines draw
+// one cell per sample, so longer windows would not fit on a line.
+const MaxHistory = 200
+
+// Process table sort orders
+const (
+	ProcessSortCPU    = "cpu"
+	ProcessSortMemory = "memory"
+)
+
 // Thresholds defines alert thresholds for different metrics
 type Thresholds struct {
 	CPU    float64 // CPU usage threshold (0-100)
 	Memory float64 // Memory usage threshold (0-100)
 	Disk   float64 // Disk usage threshold (0-100)
+	Swap   float64 // Swap usage threshold (0-100)
+	IOWait float64 // CPU iowait threshold (0-100)
+	Steal  float64 // CPU steal time threshold (0-100)
+	Inodes float64 // Filesystem inode usage threshold (0-100)
+
+	FileDescriptors float64 // System-wide file handle usage threshold (0-100)
+	PIDs            float64 // PID and thread table usage threshold (0-100)
+
+	NetErrors float64 // Network errors per second threshold, applied to each interface
+	NetDrops  float64 // Network drops per second threshold, applied to each interface
+
+	// Pressure stall thresholds, compared against the "some" avg10 value (0-100)
+	PressureCPU    float64
+	PressureMemory float64
+	PressureIO     float64
+
+	// Interfaces overrides the network thresholds for specific interfaces,
+	// keyed by lower-case interface name
+	Interfaces map[string]InterfaceThresholds
+
+	// Temperature is the critical temperature in degrees Celsius used for
+	// sensors that do not report their own critical threshold
+	Temperature float64
+
+	// Sensors overrides the critical temperature for specific sensors,
+	// keyed by lower-case "chip/label" (e.g. "coretemp/core 0")
+	Sensors map[string]float64
+}
+
+// SensorCritical returns the critical temperature for the named sensor:
+// a configured per-sensor override, else the hardware critical threshold,
+// else the configured default
+func (t *Thresholds) SensorCritical(name string, hardwareCritical float64) float64 {
+	if crit, ok := t.Sensors[strings.ToLower(name)]; ok {
+		return crit
+	}
+	if hardwareCritical > 0 {
+		return hardwareCritical
+	}
+	return t.Temperature
+}
+
+// Metric names identifying thresholds in alert rules and alert events
+const (
+	MetricCPU             = "cpu"
+	MetricMemory          = "memory"
+	MetricSwap            = "swap"
+	MetricDisk            = "disk"
+	MetricInodes          = "inodes"
+	MetricIOWait          = "iowait"
+	MetricSteal           = "steal"
+	MetricFileDescriptors = "fileDescriptors"
+	MetricPIDs            = "pids"
+	MetricNetErrors       = "netErrors"
+	MetricNetDrops        = "netDrops"
+	MetricPressureCPU     = "pressureCPU"
+	MetricPressureMemory  = "pressureMemory"
+	MetricPressureIO      = "pressureIO"
+	MetricTemperature     = "temperature"
+)
+
+// AlertMetrics lists the metrics that alert rules can be defined for
+var AlertMetrics = []string{
+	MetricCPU, MetricMemory, MetricSwap, MetricDisk, MetricInodes,
+	MetricIOWait, MetricSteal, MetricFileDescriptors, MetricPIDs,
+	MetricNetErrors, MetricNetDrops,
+	MetricPressureCPU, MetricPressureMemory, MetricPressureIO,
+	MetricTemperature,
+}
+
+// Alerts configures how threshold crossings become alerts
+type Alerts struct {
+	For   time.Duration        // Default time a threshold must stay exceeded before its alert fires
+	Rules map[string]AlertRule // Per-metric overrides, keyed by metric name (e.g. "disk")
+}
+
+// AlertRule tunes the alert for one metric
+type AlertRule struct {
+	For   time.Duration // Time the threshold must stay exceeded before the alert fires
+	Clear float64       // Value at or below which a firing alert resolves; 0 resolves at the threshold
+}
+
+// Rule returns the alert settings for the named metric
+func (a *Alerts) Rule(metric string) AlertRule {
+	if rule, ok := a.Rules[metric]; ok {
+		return rule
+	}
+	return AlertRule{For: a.For}
+}
+
+// Notify configures alert notifications
+type Notify struct {
+	Webhooks []Webhook // Webhooks to POST alert transitions to
+	Hooks    []Hook    // Commands to run on alert transitions
+
+	HookConcurrency int // Maximum number of hook commands running at once
+
+	Timeout time.Duration // Time limit for each delivery attempt
+	Retries int           // Further attempts after a failed delivery
+	Backoff time.Duration // Wait before the first retry, doubled for each further retry
+}
+
+// Webhook defines an alert webhook
+type Webhook struct {
+	URL string
+
+	// Template is a Go text/template for the request body, executed with
+	// the alert payload. Empty sends the payload as JSON.
+	Template string
+}
+
+// Hook defines a command to run when alerts fire or resolve
+type Hook struct {
+	Command string   // Shell command, run with sh -c
+	On      []string // Alert states that run the command: "firing", "resolved" or both (the default)
+	Metrics []string // Metrics whose alerts run the command, empty for all
+
+	Timeout  time.Duration // Time after which the command is killed
+	Cooldown time.Duration // Minimum time between runs for the same alert and state
+}
+
+// Default hook settings
+const (
+	DefaultHookTimeout     = 30 * time.Second
+	DefaultHookCooldown    = 5 * time.Minute
+	DefaultHookConcurrency = 4
+)
+
+// Check configures the check command. Thresholds are its critical levels;
+// the warning levels sit below them.
+type Check struct {
+	// Warning sets the warning level of individual metrics, keyed by
+	// metric name (e.g. "disk")
+	Warning map[string]float64
+
+	// WarningRatio is the fraction of the critical level at which metrics
+	// without their own warning level warn
+	WarningRatio float64
+}
+
+// DefaultWarningRatio is the default Check.WarningRatio
+const DefaultWarningRatio = 0.9
+
+// WarningLevel returns the warning level of a metric whose critical level
+// is critical
+func (c *Check) WarningLevel(metric string, critical float64) float64 {
+	if warning, ok := c.Warning[metric]; ok {
+		return warning
+	}
+	return critical * c.WarningRatio
+}
+
+// InterfaceThresholds defines network alert thresholds for a single interface
+type InterfaceThresholds struct {
+	Errors float64 // Errors per second threshold
+	Drops  float64 // Drops per second threshold
+}
+
+// ForInterface returns the network thresholds that apply to the named
+// interface. Names are matched case-insensitively.
+func (t *Thresholds) ForInterface(name string) InterfaceThresholds {
+	if override, ok := t.Interfaces[strings.ToLower(name)]; ok {
+		return override
+	}
+	return InterfaceThresholds{
+		Errors: t.NetErrors,
+		Drops:  t.NetDrops,
+	}
 }
 
 // NewDefaultConfig returns a Config with default values
@@ -23,11 +234,48 @@ func NewDefaultConfig() *Config {
 	return &Config{
 		Interval: 1 * time.Second,
 		JSONMode: false,
+		History:  60,
 		LogFile:  "",
+		Listen:   ":9110",
+		ProcRoot: "/proc",
+		SysRoot:  "/sys",
+		HostRoot: "/",
+
+		TopProcesses: 10,
+		ProcessSort:  ProcessSortCPU,
+
+		Check: Check{
+			WarningRatio: DefaultWarningRatio,
+		},
+
+		Notify: Notify{
+			HookConcurrency: DefaultHookConcurrency,
+
+			Timeout: 5 * time.Second,
+			Retries: 3,
+			Backoff: 1 * time.Second,
+		},
+
 		Thresholds: Thresholds{
 			CPU:    80.0,
 			Memory: 85.0,
 			Disk:   90.0,
+			Swap:   50.0,
+			IOWait: 20.0,
+			Steal:  10.0,
+			Inodes: 90.0,
+
+			FileDescriptors: 80.0,
+			PIDs:            80.0,
+
+			NetErrors: 1.0,
+			NetDrops:  10.0,
+
+			PressureCPU:    25.0,
+			PressureMemory: 10.0,
+			PressureIO:     20.0,
+
+			Temperature: 85.0,
 		},
 	}
 }
@@ -3,8 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -43,11 +45,61 @@ func LoadFromFile(path string) (*Config, error) {
 		config.JSONMode = v.GetBool("json")
 	}
 
+	// Load interactive mode
+	if v.IsSet("interactive") {
+		config.Interactive = v.GetBool("interactive")
+	}
+
+	// Load history window
+	if v.IsSet("history") {
+		config.History = v.GetInt("history")
+	}
+
 	// Load log file
 	if v.IsSet("logFile") {
 		config.LogFile = v.GetString("logFile")
 	}
 
+	// Load exporter listen address
+	if v.IsSet("listen") {
+		config.Listen = v.GetString("listen")
+	}
+
+	// Load pseudo-filesystem roots
+	if v.IsSet("procRoot") {
+		config.ProcRoot = v.GetString("procRoot")
+	}
+	if v.IsSet("sysRoot") {
+		config.SysRoot = v.GetString("sysRoot")
+	}
+	if v.IsSet("hostRoot") {
+		config.HostRoot = v.GetString("hostRoot")
+	}
+
+	// Load cgroup options
+	if v.IsSet("cgroup.path") {
+		config.CgroupPath = v.GetString("cgroup.path")
+	}
+	if v.IsSet("cgroup.useLimits") {
+		config.CgroupLimits = v.GetBool("cgroup.useLimits")
+	}
+
+	// Load Docker options
+	if v.IsSet("docker.enabled") {
+		config.Docker = v.GetBool("docker.enabled")
+	}
+	if v.IsSet("docker.socket") {
+		config.DockerSocket = v.GetString("docker.socket")
+	}
+
+	// Load process table options
+	if v.IsSet("topProcesses") {
+		config.TopProcesses = v.GetInt("topProcesses")
+	}
+	if v.IsSet("processSort") {
+		config.ProcessSort = v.GetString("processSort")
+	}
+
 	// Load thresholds
 	if v.IsSet("thresholds.cpu") {
 		config.Thresholds.CPU = v.GetFloat64("thresholds.cpu")
@@ -58,6 +110,109 @@ func LoadFromFile(path string) (*Config, error) {
 	if v.IsSet("thresholds.disk") {
 		config.Thresholds.Disk = v.GetFloat64("thresholds.disk")
 	}
+	if v.IsSet("thresholds.swap") {
+		config.Thresholds.Swap = v.GetFloat64("thresholds.swap")
+	}
+	if v.IsSet("thresholds.iowait") {
+		config.Thresholds.IOWait = v.GetFloat64("thresholds.iowait")
+	}
+	if v.IsSet("thresholds.steal") {
+		config.Thresholds.Steal = v.GetFloat64("thresholds.steal")
+	}
+	if v.IsSet("thresholds.inodes") {
+		config.Thresholds.Inodes = v.GetFloat64("thresholds.inodes")
+	}
+	if v.IsSet("thresholds.fileDescriptors") {
+		config.Thresholds.FileDescriptors = v.GetFloat64("thresholds.fileDescriptors")
+	}
+	if v.IsSet("thresholds.pids") {
+		config.Thresholds.PIDs = v.GetFloat64("thresholds.pids")
+	}
+	if v.IsSet("thresholds.netErrors") {
+		config.Thresholds.NetErrors = v.GetFloat64("thresholds.netErrors")
+	}
+	if v.IsSet("thresholds.netDrops") {
+		config.Thresholds.NetDrops = v.GetFloat64("thresholds.netDrops")
+	}
+	if v.IsSet("thresholds.pressure.cpu") {
+		config.Thresholds.PressureCPU = v.GetFloat64("thresholds.pressure.cpu")
+	}
+	if v.IsSet("thresholds.pressure.memory") {
+		config.Thresholds.PressureMemory = v.GetFloat64("thresholds.pressure.memory")
+	}
+	if v.IsSet("thresholds.pressure.io") {
+		config.Thresholds.PressureIO = v.GetFloat64("thresholds.pressure.io")
+	}
+	if v.IsSet("thresholds.temperature") {
+		config.Thresholds.Temperature = v.GetFloat64("thresholds.temperature")
+	}
+	if v.IsSet("thresholds.sensors") {
+		config.Thresholds.Sensors = loadSensorThresholds(v.GetStringMap("thresholds.sensors"))
+	}
+	if v.IsSet("thresholds.interfaces") {
+		interfaces, err := loadInterfaceThresholds(v.Get("thresholds.interfaces"), config.Thresholds)
+		if err != nil {
+			return nil, err
+		}
+		config.Thresholds.Interfaces = interfaces
+	}
+
+	// Load alert rules
+	if v.IsSet("alerts.for") {
+		duration, err := time.ParseDuration(v.GetString("alerts.for"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid alerts.for format: %w", err)
+		}
+		config.Alerts.For = duration
+	}
+	if v.IsSet("alerts.rules") {
+		rules, err := loadAlertRules(v.GetStringMap("alerts.rules"), config.Alerts.For)
+		if err != nil {
+			return nil, err
+		}
+		config.Alerts.Rules = rules
+	}
+
+	// Load notification options
+	if v.IsSet("notify.webhooks") {
+		config.Notify.Webhooks = loadWebhooks(v.Get("notify.webhooks"))
+	}
+	for _, key := range []struct {
+		name string
+		dst  *time.Duration
+	}{
+		{"notify.timeout", &config.Notify.Timeout},
+		{"notify.backoff", &config.Notify.Backoff},
+	} {
+		if v.IsSet(key.name) {
+			duration, err := time.ParseDuration(v.GetString(key.name))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s format: %w", key.name, err)
+			}
+			*key.dst = duration
+		}
+	}
+	if v.IsSet("notify.retries") {
+		config.Notify.Retries = v.GetInt("notify.retries")
+	}
+	if v.IsSet("notify.hooks") {
+		hooks, err := loadHooks(v.Get("notify.hooks"))
+		if err != nil {
+			return nil, err
+		}
+		config.Notify.Hooks = hooks
+	}
+	if v.IsSet("notify.hookConcurrency") {
+		config.Notify.HookConcurrency = v.GetInt("notify.hookConcurrency")
+	}
+
+	// Load check warning levels
+	if v.IsSet("check.warning") {
+		config.Check.Warning = loadWarningLevels(v.GetStringMap("check.warning"))
+	}
+	if v.IsSet("check.warningRatio") {
+		config.Check.WarningRatio = v.GetFloat64("check.warningRatio")
+	}
 
 	// Validate configuration
 	if err := ValidateConfig(config); err != nil {
@@ -67,6 +222,28 @@ func LoadFromFile(path string) (*Config, error) {
 	return config, nil
 }
 
+// Environment variables that override configuration file settings
+const (
+	EnvProcRoot = "SYSMON_PROC_ROOT"
+	EnvSysRoot  = "SYSMON_SYS_ROOT"
+	EnvHostRoot = "SYSMON_HOST_ROOT"
+)
+
+// ApplyEnvironment overrides configuration with values from environment
+// variables. Command-line flags should be applied afterwards so they take
+// precedence.
+func ApplyEnvironment(config *Config) {
+	if root := os.Getenv(EnvProcRoot); root != "" {
+		config.ProcRoot = root
+	}
+	if root := os.Getenv(EnvSysRoot); root != "" {
+		config.SysRoot = root
+	}
+	if root := os.Getenv(EnvHostRoot); root != "" {
+		config.HostRoot = root
+	}
+}
+
 // ValidateConfig validates configuration values
 func ValidateConfig(config *Config) error {
 	// Validate interval
@@ -77,6 +254,52 @@ func ValidateConfig(config *Config) error {
 		return fmt.Errorf("interval too large (max 1 hour), got: %v", config.Interval)
 	}
 
+	// Interactive mode drives the terminal display
+	if config.Interactive && config.JSONMode {
+		return fmt.Errorf("interactive mode cannot be combined with JSON output")
+	}
+
+	// Validate bounded runs
+	if config.Count < 0 {
+		return fmt.Errorf("count must not be negative, got: %d", config.Count)
+	}
+	if config.Duration < 0 {
+		return fmt.Errorf("duration must not be negative, got: %v", config.Duration)
+	}
+	if config.Interactive && config.Bounded() {
+		return fmt.Errorf("interactive mode cannot be combined with a sample count or duration")
+	}
+
+	// Validate history window
+	if config.History < 0 || config.History > MaxHistory {
+		return fmt.Errorf("history must be between 0 and %d samples, got: %d", MaxHistory, config.History)
+	}
+
+	// Validate exporter listen address
+	if config.Listen == "" {
+		return fmt.Errorf("listen address must not be empty")
+	}
+
+	// Validate pseudo-filesystem roots
+	if config.ProcRoot == "" {
+		return fmt.Errorf("procRoot must not be empty")
+	}
+	if config.SysRoot == "" {
+		return fmt.Errorf("sysRoot must not be empty")
+	}
+	if config.HostRoot == "" {
+		return fmt.Errorf("hostRoot must not be empty")
+	}
+
+	// Validate process table options
+	if config.TopProcesses < 0 {
+		return fmt.Errorf("topProcesses must not be negative, got: %d", config.TopProcesses)
+	}
+	if config.ProcessSort != ProcessSortCPU && config.ProcessSort != ProcessSortMemory {
+		return fmt.Errorf("processSort must be %q or %q, got: %q",
+			ProcessSortCPU, ProcessSortMemory, config.ProcessSort)
+	}
+
 	// Validate thresholds
 	if err := validateThreshold("CPU", config.Thresholds.CPU); err != nil {
 		return err
@@ -87,10 +310,146 @@ func ValidateConfig(config *Config) error {
 	if err := validateThreshold("Disk", config.Thresholds.Disk); err != nil {
 		return err
 	}
+	if err := validateThreshold("Swap", config.Thresholds.Swap); err != nil {
+		return err
+	}
+	if err := validateThreshold("IOWait", config.Thresholds.IOWait); err != nil {
+		return err
+	}
+	if err := validateThreshold("Steal", config.Thresholds.Steal); err != nil {
+		return err
+	}
+	if err := validateThreshold("Inodes", config.Thresholds.Inodes); err != nil {
+		return err
+	}
+	if err := validateThreshold("File descriptor", config.Thresholds.FileDescriptors); err != nil {
+		return err
+	}
+	if err := validateThreshold("PID", config.Thresholds.PIDs); err != nil {
+		return err
+	}
+	if err := validateThreshold("CPU pressure", config.Thresholds.PressureCPU); err != nil {
+		return err
+	}
+	if err := validateThreshold("Memory pressure", config.Thresholds.PressureMemory); err != nil {
+		return err
+	}
+	if err := validateThreshold("IO pressure", config.Thresholds.PressureIO); err != nil {
+		return err
+	}
+	if err := validateRate("Network error", config.Thresholds.NetErrors); err != nil {
+		return err
+	}
+	if err := validateRate("Network drop", config.Thresholds.NetDrops); err != nil {
+		return err
+	}
+	if config.Thresholds.Temperature <= 0 {
+		return fmt.Errorf("temperature threshold must be positive, got: %.2f", config.Thresholds.Temperature)
+	}
+	for name, crit := range config.Thresholds.Sensors {
+		if crit <= 0 {
+			return fmt.Errorf("sensor %s threshold must be positive, got: %.2f", name, crit)
+		}
+	}
+	for name, t := range config.Thresholds.Interfaces {
+		if err := validateRate(name+" error", t.Errors); err != nil {
+			return err
+		}
+		if err := validateRate(name+" drop", t.Drops); err != nil {
+			return err
+		}
+	}
+
+	// Validate alert rules
+	if config.Alerts.For < 0 {
+		return fmt.Errorf("alerts.for must not be negative, got: %v", config.Alerts.For)
+	}
+	for metric, rule := range config.Alerts.Rules {
+		if !isAlertMetric(metric) {
+			return fmt.Errorf("unknown alert rule metric %q (valid: %s)", metric, strings.Join(AlertMetrics, ", "))
+		}
+		if rule.For < 0 {
+			return fmt.Errorf("alert rule %s: for must not be negative, got: %v", metric, rule.For)
+		}
+		if rule.Clear < 0 {
+			return fmt.Errorf("alert rule %s: clear must not be negative, got: %.2f", metric, rule.Clear)
+		}
+	}
+
+	// Validate notifications
+	for i, webhook := range config.Notify.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("webhook %d has no url", i+1)
+		}
+	}
+	if config.Notify.Timeout <= 0 {
+		return fmt.Errorf("notify.timeout must be positive, got: %v", config.Notify.Timeout)
+	}
+	if config.Notify.Retries < 0 {
+		return fmt.Errorf("notify.retries must not be negative, got: %d", config.Notify.Retries)
+	}
+	if config.Notify.Backoff < 0 {
+		return fmt.Errorf("notify.backoff must not be negative, got: %v", config.Notify.Backoff)
+	}
+	for i, hook := range config.Notify.Hooks {
+		if err := validateHook(hook); err != nil {
+			return fmt.Errorf("hook %d: %w", i+1, err)
+		}
+	}
+	if config.Notify.HookConcurrency < 1 {
+		return fmt.Errorf("notify.hookConcurrency must be at least 1, got: %d", config.Notify.HookConcurrency)
+	}
+
+	// Validate check warning levels
+	for metric, level := range config.Check.Warning {
+		if !isAlertMetric(metric) {
+			return fmt.Errorf("unknown check warning metric %q (valid: %s)", metric, strings.Join(AlertMetrics, ", "))
+		}
+		if level < 0 {
+			return fmt.Errorf("check warning level for %s must not be negative, got: %.2f", metric, level)
+		}
+	}
+	if config.Check.WarningRatio <= 0 || config.Check.WarningRatio > 1 {
+		return fmt.Errorf("check.warningRatio must be greater than 0 and at most 1, got: %.2f", config.Check.WarningRatio)
+	}
 
 	return nil
 }
 
+// validateHook checks a command hook definition
+func validateHook(hook Hook) error {
+	if strings.TrimSpace(hook.Command) == "" {
+		return fmt.Errorf("command must not be empty")
+	}
+	for _, state := range hook.On {
+		if state != "firing" && state != "resolved" {
+			return fmt.Errorf("on must list \"firing\" or \"resolved\", got: %q", state)
+		}
+	}
+	for _, metric := range hook.Metrics {
+		if !isAlertMetric(metric) {
+			return fmt.Errorf("unknown metric %q (valid: %s)", metric, strings.Join(AlertMetrics, ", "))
+		}
+	}
+	if hook.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got: %v", hook.Timeout)
+	}
+	if hook.Cooldown < 0 {
+		return fmt.Errorf("cooldown must not be negative, got: %v", hook.Cooldown)
+	}
+	return nil
+}
+
+// isAlertMetric reports whether alert rules can be defined for the metric
+func isAlertMetric(metric string) bool {
+	for _, name := range AlertMetrics {
+		if name == metric {
+			return true
+		}
+	}
+	return false
+}
+
 // validateThreshold checks if a threshold value is in valid range [0, 100]
 func validateThreshold(name string, value float64) error {
 	if value < 0 || value > 100 {
@@ -99,6 +458,165 @@ func validateThreshold(name string, value float64) error {
 	return nil
 }
 
+// validateRate checks if a per-second rate threshold is non-negative
+func validateRate(name string, value float64) error {
+	if value < 0 {
+		return fmt.Errorf("%s rate threshold must not be negative, got: %.2f", name, value)
+	}
+	return nil
+}
+
+// loadInterfaceThresholds parses the per-interface network threshold
+// overrides, a list of objects with "name", "errors" and "drops" keys.
+// Interface names are list values rather than keys because viper
+// lower-cases keys and splits them on dots, which would break names like
+// "enP1s0" and "eth0.100". Values not set for an interface fall back to
+// the global network thresholds.
+func loadInterfaceThresholds(raw interface{}, defaults Thresholds) (map[string]InterfaceThresholds, error) {
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("thresholds.interfaces must be a list of {name, errors, drops} entries")
+	}
+
+	result := make(map[string]InterfaceThresholds, len(values))
+	for _, value := range values {
+		settings := cast.ToStringMap(value)
+		name := cast.ToString(settings["name"])
+		if name == "" {
+			return nil, fmt.Errorf("thresholds.interfaces entry without a name: %v", value)
+		}
+
+		t := InterfaceThresholds{
+			Errors: defaults.NetErrors,
+			Drops:  defaults.NetDrops,
+		}
+		if errors, ok := settings["errors"]; ok {
+			t.Errors = cast.ToFloat64(errors)
+		}
+		if drops, ok := settings["drops"]; ok {
+			t.Drops = cast.ToFloat64(drops)
+		}
+		result[strings.ToLower(name)] = t
+	}
+	return result, nil
+}
+
+// loadAlertRules parses per-metric alert rules. Metric names are matched
+// case-insensitively. Rules without a "for" use the default duration.
+func loadAlertRules(raw map[string]interface{}, defaultFor time.Duration) (map[string]AlertRule, error) {
+	result := make(map[string]AlertRule, len(raw))
+	for key, value := range raw {
+		metric := canonicalMetric(key)
+
+		rule := AlertRule{For: defaultFor}
+		settings := cast.ToStringMap(value)
+		if forValue, ok := settings["for"]; ok {
+			duration, err := time.ParseDuration(cast.ToString(forValue))
+			if err != nil {
+				return nil, fmt.Errorf("invalid alert rule %s for format: %w", metric, err)
+			}
+			rule.For = duration
+		}
+		if clear, ok := settings["clear"]; ok {
+			rule.Clear = cast.ToFloat64(clear)
+		}
+		result[metric] = rule
+	}
+	return result, nil
+}
+
+// loadWebhooks parses the webhook list. Each entry is either a URL or an
+// object with "url" and "template" keys.
+func loadWebhooks(raw interface{}) []Webhook {
+	var result []Webhook
+	for _, value := range cast.ToSlice(raw) {
+		if url, ok := value.(string); ok {
+			result = append(result, Webhook{URL: url})
+			continue
+		}
+		settings := cast.ToStringMap(value)
+		result = append(result, Webhook{
+			URL:      cast.ToString(settings["url"]),
+			Template: cast.ToString(settings["template"]),
+		})
+	}
+	return result
+}
+
+// loadHooks parses the command hook list. Each entry is either a command
+// or an object with "command", "on", "metrics", "timeout" and "cooldown"
+// keys.
+func loadHooks(raw interface{}) ([]Hook, error) {
+	var result []Hook
+	for _, value := range cast.ToSlice(raw) {
+		hook := Hook{
+			Timeout:  DefaultHookTimeout,
+			Cooldown: DefaultHookCooldown,
+		}
+		if command, ok := value.(string); ok {
+			hook.Command = command
+			result = append(result, hook)
+			continue
+		}
+
+		settings := cast.ToStringMap(value)
+		hook.Command = cast.ToString(settings["command"])
+		hook.On = cast.ToStringSlice(settings["on"])
+		for _, metric := range cast.ToStringSlice(settings["metrics"]) {
+			hook.Metrics = append(hook.Metrics, canonicalMetric(metric))
+		}
+		for _, key := range []struct {
+			name string
+			dst  *time.Duration
+		}{
+			{"timeout", &hook.Timeout},
+			{"cooldown", &hook.Cooldown},
+		} {
+			if value, ok := settings[key.name]; ok {
+				duration, err := time.ParseDuration(cast.ToString(value))
+				if err != nil {
+					return nil, fmt.Errorf("invalid hook %s format: %w", key.name, err)
+				}
+				*key.dst = duration
+			}
+		}
+		result = append(result, hook)
+	}
+	return result, nil
+}
+
+// loadWarningLevels parses per-metric check warning levels. Metric names
+// are matched case-insensitively.
+func loadWarningLevels(raw map[string]interface{}) map[string]float64 {
+	result := make(map[string]float64, len(raw))
+	for key, value := range raw {
+		result[canonicalMetric(key)] = cast.ToFloat64(value)
+	}
+	return result
+}
+
+// canonicalMetric returns the alert metric name matching key regardless
+// of case, as viper lower-cases map keys, or key itself if none does
+func canonicalMetric(key string) string {
+	for _, name := range AlertMetrics {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+	}
+	return key
+}
+
+// loadSensorThresholds parses per-sensor critical temperature overrides.
+// Keys are lower-cased to match the case-insensitive lookup in
+// Thresholds.SensorCritical.
+func loadSensorThresholds(raw map[string]interface{}) map[string]float64 {
+	result := make(map[string]float64, len(raw))
+	for name, value := range raw {
+		result[strings.ToLower(name)] = cast.ToFloat64(value)
+	}
+	return result
+}
+
 // MergeWithFlags merges configuration with command-line flags (flags take precedence)
 func MergeWithFlags(config *Config, interval time.Duration, jsonMode bool, logFile string,
 	cpuThreshold, memThreshold, diskThreshold float64) (*Config, error) {
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a configuration file with the given name to a
+// temporary directory and returns its path
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	// Both fixtures hold the same settings, one in each format
+	for _, path := range []string{"testdata/full.yaml", "testdata/full.json"} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			cfg, err := LoadFromFile(path)
+			if err != nil {
+				t.Fatalf("LoadFromFile() error = %v", err)
+			}
+
+			if cfg.Interval != 2*time.Second {
+				t.Errorf("Interval = %v, want 2s", cfg.Interval)
+			}
+
+			// Interface names keep their dots and are looked up in any case
+			wantInterfaces := map[string]InterfaceThresholds{
+				"enp1s0":   {Errors: 0.5, Drops: 50},
+				"eth0.100": {Errors: 1, Drops: 20},
+			}
+			if !reflect.DeepEqual(cfg.Thresholds.Interfaces, wantInterfaces) {
+				t.Errorf("Interfaces = %+v, want %+v", cfg.Thresholds.Interfaces, wantInterfaces)
+			}
+			if got := cfg.Thresholds.ForInterface("enP1s0"); got.Errors != 0.5 {
+				t.Errorf("ForInterface(enP1s0) = %+v, want the override", got)
+			}
+			if got := cfg.Thresholds.SensorCritical("coretemp/Package id 0", 100); got != 90 {
+				t.Errorf("SensorCritical() = %.1f, want the override 90", got)
+			}
+
+			// Rule keys are matched to metric names whatever their case,
+			// and rules without a for use the default
+			wantRules := map[string]AlertRule{
+				MetricNetErrors: {For: time.Minute, Clear: 0.5},
+				MetricDisk:      {For: 30 * time.Second, Clear: 85},
+			}
+			if !reflect.DeepEqual(cfg.Alerts.Rules, wantRules) {
+				t.Errorf("Rules = %+v, want %+v", cfg.Alerts.Rules, wantRules)
+			}
+
+			wantWebhooks := []Webhook{
+				{URL: "https://hooks.example.com/plain"},
+				{URL: "https://hooks.example.com/templated", Template: `{"text": {{json .Summary}}}`},
+			}
+			if !reflect.DeepEqual(cfg.Notify.Webhooks, wantWebhooks) {
+				t.Errorf("Webhooks = %+v, want %+v", cfg.Notify.Webhooks, wantWebhooks)
+			}
+
+			wantHooks := []Hook{
+				{Command: "/usr/local/bin/page-oncall", Timeout: DefaultHookTimeout, Cooldown: DefaultHookCooldown},
+				{
+					Command:  `logger -t sysmon "$SYSMON_METRIC"`,
+					On:       []string{"firing"},
+					Metrics:  []string{MetricDisk, MetricPressureIO},
+					Timeout:  10 * time.Second,
+					Cooldown: time.Minute,
+				},
+			}
+			if !reflect.DeepEqual(cfg.Notify.Hooks, wantHooks) {
+				t.Errorf("Hooks = %+v, want %+v", cfg.Notify.Hooks, wantHooks)
+			}
+
+			if level := cfg.Check.Warning[MetricFileDescriptors]; level != 70 || cfg.Check.WarningRatio != 0.8 {
+				t.Errorf("Check = %+v, want fileDescriptors 70 and ratio 0.8", cfg.Check)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string // Part of the expected error
+	}{
+		{"invalid interval", "c.yaml", "interval: soon\n", "invalid interval format"},
+		{"invalid default for", "c.yaml", "alerts:\n  for: 30\n", "invalid alerts.for format"},
+		{"invalid rule for", "c.yaml", "alerts:\n  rules:\n    cpu:\n      for: later\n", "invalid alert rule cpu for format"},
+		{"unknown rule metric", "c.yaml", "alerts:\n  rules:\n    gpu:\n      for: 1m\n", `unknown alert rule metric "gpu"`},
+		{"invalid notify timeout", "c.yaml", "notify:\n  timeout: 5\n", "invalid notify.timeout format"},
+		{"invalid hook timeout", "c.yaml", "notify:\n  hooks:\n    - command: true\n      timeout: 5\n", "invalid hook timeout format"},
+		{"invalid hook cooldown", "c.json", `{"notify": {"hooks": [{"command": "true", "cooldown": "x"}]}}`, "invalid hook cooldown format"},
+		{"unknown hook metric", "c.yaml", "notify:\n  hooks:\n    - command: true\n      metrics: [gpu]\n", `hook 1: unknown metric "gpu"`},
+		{"unknown hook state", "c.yaml", "notify:\n  hooks:\n    - command: true\n      on: [pending]\n", `hook 1: on must list`},
+		{"webhook without url", "c.json", `{"notify": {"webhooks": [{"template": "x"}]}}`, "webhook 1 has no url"},
+		{"unknown check metric", "c.yaml", "check:\n  warning:\n    gpu: 50\n", `unknown check warning metric "gpu"`},
+		{"interfaces as a map", "c.yaml", "thresholds:\n  interfaces:\n    eth0:\n      errors: 1\n", "must be a list"},
+		{"interface without a name", "c.json", `{"thresholds": {"interfaces": [{"errors": 1}]}}`, "entry without a name"},
+		{"negative interface rate", "c.yaml", "thresholds:\n  interfaces:\n    - name: eth0\n      drops: -1\n", "eth0 drop rate threshold must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFromFile(writeConfig(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadFromFile() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileMissing(t *testing.T) {
+	if _, err := LoadFromFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadFromFile() error = nil, want not found")
+	}
+
+	cfg, err := LoadFromFile("")
+	if err != nil || !reflect.DeepEqual(cfg, NewDefaultConfig()) {
+		t.Errorf("LoadFromFile(\"\") = %+v, %v, want the defaults", cfg, err)
+	}
+}
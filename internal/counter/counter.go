@@ -0,0 +1,13 @@
+// Package counter handles cumulative counters such as those read from
+// /proc and the Docker Engine API.
+package counter
+
+// Delta returns the difference between two readings of a counter,
+// treating a decrease (counter wrap, device reset or container restart)
+// as zero
+func Delta(prev, curr uint64) uint64 {
+	if curr < prev {
+		return 0
+	}
+	return curr - prev
+}
@@ -0,0 +1,21 @@
+package counter
+
+import "testing"
+
+func TestDelta(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, curr uint64
+		want       uint64
+	}{
+		{"increase", 10, 25, 15},
+		{"unchanged", 10, 10, 0},
+		{"reset", 25, 10, 0},
+	}
+
+	for _, tt := range tests {
+		if got := Delta(tt.prev, tt.curr); got != tt.want {
+			t.Errorf("%s: Delta(%d, %d) = %d, want %d", tt.name, tt.prev, tt.curr, got, tt.want)
+		}
+	}
+}
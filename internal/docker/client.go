@@ -0,0 +1,267 @@
+// Package docker collects per-container resource usage from the Docker
+// Engine HTTP API over its unix socket.
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/counter"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// DefaultSocket is the default Docker Engine API socket
+const DefaultSocket = "/var/run/docker.sock"
+
+// maxConcurrentStats limits the number of stats requests in flight, so
+// hosts with many containers don't open a connection per container
+const maxConcurrentStats = 8
+
+// Client queries the Docker Engine API and tracks the previous sample of
+// each container to compute CPU usage and I/O rates
+type Client struct {
+	socket string
+	http   *http.Client
+
+	mu   sync.Mutex
+	prev map[string]sample // Previous counters by full container ID
+}
+
+// sample holds the counters of a container at a point in time
+type sample struct {
+	read       time.Time
+	cpu        uint64 // Total CPU time in nanoseconds
+	netRx      uint64
+	netTx      uint64
+	blockRead  uint64
+	blockWrite uint64
+}
+
+// NewClient creates a client for the Docker Engine API listening on the
+// given unix socket. An empty path selects DefaultSocket.
+func NewClient(socket string) *Client {
+	if socket == "" {
+		socket = DefaultSocket
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+		MaxIdleConnsPerHost: maxConcurrentStats,
+	}
+
+	return &Client{
+		socket: socket,
+		http:   &http.Client{Transport: transport},
+		prev:   make(map[string]sample),
+	}
+}
+
+// container is an entry of GET /containers/json
+type container struct {
+	ID     string   `json:"Id"`
+	Names  []string `json:"Names"`
+	Image  string   `json:"Image"`
+	Status string   `json:"Status"`
+}
+
+// containerStats is the subset of GET /containers/{id}/stats used here
+type containerStats struct {
+	Read     time.Time `json:"read"`
+	CPUStats struct {
+		CPUUsage struct {
+			TotalUsage uint64 `json:"total_usage"`
+		} `json:"cpu_usage"`
+	} `json:"cpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IOServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+// GetContainerStats lists running containers and retrieves their resource
+// usage. Containers whose stats cannot be read, for example because they
+// stopped while being queried, are left out.
+func (c *Client) GetContainerStats(ctx context.Context) ([]models.ContainerStats, error) {
+	var containers []container
+	if err := c.get(ctx, "/containers/json", &containers); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.ContainerStats, len(containers))
+	ok := make([]bool, len(containers))
+	sem := make(chan struct{}, maxConcurrentStats)
+	var wg sync.WaitGroup
+
+	for i, ctr := range containers {
+		wg.Add(1)
+		go func(i int, ctr container) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// one-shot skips the daemon's wait for a second CPU sample;
+			// CPU usage is computed against our own previous sample instead
+			var raw containerStats
+			path := "/containers/" + ctr.ID + "/stats?stream=false&one-shot=true"
+			if err := c.get(ctx, path, &raw); err != nil {
+				return
+			}
+			results[i] = c.convert(ctr, raw)
+			ok[i] = true
+		}(i, ctr)
+	}
+	wg.Wait()
+
+	stats := make([]models.ContainerStats, 0, len(containers))
+	seen := make(map[string]bool, len(containers))
+	for i, ctr := range containers {
+		if ok[i] {
+			stats = append(stats, results[i])
+			seen[ctr.ID] = true
+		}
+	}
+
+	// Forget containers that are gone
+	c.mu.Lock()
+	for id := range c.prev {
+		if !seen[id] {
+			delete(c.prev, id)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+	return stats, nil
+}
+
+// convert builds container statistics from an API stats response and
+// computes rates against the container's previous sample
+func (c *Client) convert(ctr container, raw containerStats) models.ContainerStats {
+	stats := models.ContainerStats{
+		ID:     shortID(ctr.ID),
+		Image:  ctr.Image,
+		Status: ctr.Status,
+		PIDs:   raw.PidsStats.Current,
+	}
+	if len(ctr.Names) > 0 {
+		stats.Name = strings.TrimPrefix(ctr.Names[0], "/")
+	}
+
+	// Like `docker stats`, exclude inactive page cache from memory usage.
+	// cgroup v1 reports total_inactive_file, cgroup v2 inactive_file.
+	mem := raw.MemoryStats
+	inactive, found := mem.Stats["total_inactive_file"]
+	if !found {
+		inactive = mem.Stats["inactive_file"]
+	}
+	stats.MemoryUsage = mem.Usage
+	if inactive < mem.Usage {
+		stats.MemoryUsage = mem.Usage - inactive
+	}
+	stats.MemoryLimit = mem.Limit
+	stats.MemoryPercent = models.CalculatePercentage(stats.MemoryUsage, stats.MemoryLimit)
+
+	for _, n := range raw.Networks {
+		stats.NetRxBytes += n.RxBytes
+		stats.NetTxBytes += n.TxBytes
+	}
+
+	// Ops are "Read"/"Write" on cgroup v1 and "read"/"write" on cgroup v2
+	for _, entry := range raw.BlkioStats.IOServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+
+	cpu := raw.CPUStats.CPUUsage.TotalUsage
+	stats.CPUTime = time.Duration(cpu)
+
+	curr := sample{
+		read:       raw.Read,
+		cpu:        cpu,
+		netRx:      stats.NetRxBytes,
+		netTx:      stats.NetTxBytes,
+		blockRead:  stats.BlockRead,
+		blockWrite: stats.BlockWrite,
+	}
+
+	c.mu.Lock()
+	prev, exists := c.prev[ctr.ID]
+	c.prev[ctr.ID] = curr
+	c.mu.Unlock()
+
+	timeDelta := curr.read.Sub(prev.read).Seconds()
+	if !exists || timeDelta <= 0 {
+		return stats
+	}
+
+	stats.CPUPercent = float64(counter.Delta(prev.cpu, curr.cpu)) / 1e9 / timeDelta * 100.0
+	stats.NetRxRate = float64(counter.Delta(prev.netRx, curr.netRx)) / timeDelta
+	stats.NetTxRate = float64(counter.Delta(prev.netTx, curr.netTx)) / timeDelta
+	stats.BlockReadRate = float64(counter.Delta(prev.blockRead, curr.blockRead)) / timeDelta
+	stats.BlockWriteRate = float64(counter.Delta(prev.blockWrite, curr.blockWrite)) / timeDelta
+	return stats
+}
+
+// get performs a GET request against the API and decodes the JSON response
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	// The host is ignored by the unix socket dialer
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("docker API request to %s failed: %w", c.socket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("docker API %s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode docker API response for %s: %w", path, err)
+	}
+	return nil
+}
+
+// shortID truncates a container ID to the 12 characters shown by the CLI
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,221 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	webID   = "4f66ad9a0b2ef7c3d8a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f607"
+	dbID    = "9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c"
+	goneID  = "deadbeefcafe0000000000000000000000000000000000000000000000000000"
+	readFmt = "2006-01-02T15:04:05.000000000Z"
+)
+
+// fakeDaemon serves a minimal Docker Engine API on a unix socket. Each
+// stats request advances the counters of the container by one step.
+type fakeDaemon struct {
+	mu    sync.Mutex
+	steps map[string]int
+}
+
+func (d *fakeDaemon) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+			{"Id": %q, "Names": ["/web"], "Image": "nginx:1.27", "Status": "Up 3 hours"},
+			{"Id": %q, "Names": ["/db"], "Image": "postgres:16", "Status": "Up 2 days"},
+			{"Id": %q, "Names": ["/gone"], "Image": "busybox", "Status": "Up 1 second"}
+		]`, webID, dbID, goneID)
+	})
+
+	mux.HandleFunc("/containers/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/containers/"), "/stats")
+		if r.URL.Query().Get("stream") != "false" {
+			http.Error(w, "streaming not supported by fake daemon", http.StatusBadRequest)
+			return
+		}
+
+		d.mu.Lock()
+		step := d.steps[id]
+		d.steps[id]++
+		d.mu.Unlock()
+
+		read := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC).Add(time.Duration(step) * 2 * time.Second)
+
+		switch id {
+		case webID:
+			// cgroup v2 style: inactive_file and lower-case blkio ops
+			fmt.Fprintf(w, `{
+				"read": %q,
+				"cpu_stats": {"cpu_usage": {"total_usage": %d}},
+				"memory_stats": {"usage": 104857600, "limit": 1073741824, "stats": {"inactive_file": 4194304}},
+				"networks": {"eth0": {"rx_bytes": %d, "tx_bytes": %d}, "eth1": {"rx_bytes": 1000, "tx_bytes": 0}},
+				"blkio_stats": {"io_service_bytes_recursive": [
+					{"major": 8, "minor": 0, "op": "read", "value": %d},
+					{"major": 8, "minor": 0, "op": "write", "value": 4096}
+				]},
+				"pids_stats": {"current": 5}
+			}`, read.Format(readFmt), 5_000_000_000+step*1_000_000_000,
+				2000+step*20000, 500+step*10000, 8192+step*8192)
+		case dbID:
+			// cgroup v1 style: total_inactive_file and capitalised blkio ops
+			fmt.Fprintf(w, `{
+				"read": %q,
+				"cpu_stats": {"cpu_usage": {"total_usage": %d}},
+				"memory_stats": {"usage": 536870912, "limit": 2147483648, "stats": {"total_inactive_file": 268435456, "inactive_file": 1}},
+				"networks": {"eth0": {"rx_bytes": 100, "tx_bytes": 200}},
+				"blkio_stats": {"io_service_bytes_recursive": [
+					{"major": 8, "minor": 0, "op": "Read", "value": 1048576},
+					{"major": 8, "minor": 0, "op": "Write", "value": 2097152},
+					{"major": 8, "minor": 0, "op": "Total", "value": 3145728}
+				]},
+				"pids_stats": {"current": 12}
+			}`, read.Format(readFmt), 60_000_000_000+step*3_000_000_000)
+		default:
+			// Stopped between listing and querying stats
+			http.Error(w, `{"message": "No such container"}`, http.StatusNotFound)
+		}
+	})
+
+	return mux
+}
+
+// startFakeDaemon serves handler on a unix socket in a temporary directory
+// and returns the socket path
+func startFakeDaemon(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socket, err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+func TestGetContainerStats(t *testing.T) {
+	daemon := &fakeDaemon{steps: make(map[string]int)}
+	client := NewClient(startFakeDaemon(t, daemon.handler()))
+
+	stats, err := client.GetContainerStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetContainerStats() error = %v", err)
+	}
+
+	// The stopped container is skipped and the rest are sorted by name
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d, want 2: %+v", len(stats), stats)
+	}
+	db, web := stats[0], stats[1]
+	if db.Name != "db" || web.Name != "web" {
+		t.Fatalf("names = %q, %q, want db, web", db.Name, web.Name)
+	}
+
+	if web.ID != webID[:12] || web.Image != "nginx:1.27" || web.Status != "Up 3 hours" {
+		t.Errorf("web = %+v, want short ID, image and status", web)
+	}
+	if web.CPUTime != 5*time.Second || web.PIDs != 5 {
+		t.Errorf("web CPUTime/PIDs = %v/%d, want 5s/5", web.CPUTime, web.PIDs)
+	}
+
+	// 100 MB usage less 4 MB inactive_file, against a 1 GB limit
+	if web.MemoryUsage != 96*1024*1024 || web.MemoryLimit != 1024*1024*1024 {
+		t.Errorf("web memory = %d/%d, want %d/%d", web.MemoryUsage, web.MemoryLimit, 96*1024*1024, 1024*1024*1024)
+	}
+	if !almostEqual(web.MemoryPercent, 9.375) {
+		t.Errorf("web MemoryPercent = %.3f, want 9.375", web.MemoryPercent)
+	}
+
+	// Network counters are summed across networks
+	if web.NetRxBytes != 3000 || web.NetTxBytes != 500 {
+		t.Errorf("web network = %d/%d, want 3000/500", web.NetRxBytes, web.NetTxBytes)
+	}
+	if web.BlockRead != 8192 || web.BlockWrite != 4096 {
+		t.Errorf("web block I/O = %d/%d, want 8192/4096", web.BlockRead, web.BlockWrite)
+	}
+
+	// total_inactive_file takes precedence on cgroup v1, and the "Total" op is ignored
+	if db.MemoryUsage != 256*1024*1024 || !almostEqual(db.MemoryPercent, 12.5) {
+		t.Errorf("db memory = %d (%.2f%%), want %d (12.50%%)", db.MemoryUsage, db.MemoryPercent, 256*1024*1024)
+	}
+	if db.BlockRead != 1048576 || db.BlockWrite != 2097152 {
+		t.Errorf("db block I/O = %d/%d, want 1048576/2097152", db.BlockRead, db.BlockWrite)
+	}
+
+	// No previous sample yet
+	if web.CPUPercent != 0 || web.NetRxRate != 0 || web.BlockReadRate != 0 {
+		t.Errorf("first sample rates = %+v, want zero", web)
+	}
+}
+
+func TestGetContainerStatsRates(t *testing.T) {
+	daemon := &fakeDaemon{steps: make(map[string]int)}
+	client := NewClient(startFakeDaemon(t, daemon.handler()))
+
+	if _, err := client.GetContainerStats(context.Background()); err != nil {
+		t.Fatalf("first GetContainerStats() error = %v", err)
+	}
+	stats, err := client.GetContainerStats(context.Background())
+	if err != nil {
+		t.Fatalf("second GetContainerStats() error = %v", err)
+	}
+	db, web := stats[0], stats[1]
+
+	// Samples are 2s apart: web used 1s of CPU, db used 3s
+	if !almostEqual(web.CPUPercent, 50.0) || !almostEqual(db.CPUPercent, 150.0) {
+		t.Errorf("CPUPercent = web %.2f db %.2f, want 50.00 and 150.00", web.CPUPercent, db.CPUPercent)
+	}
+	if !almostEqual(web.NetRxRate, 10000) || !almostEqual(web.NetTxRate, 5000) {
+		t.Errorf("web network rates = %.2f/%.2f, want 10000/5000", web.NetRxRate, web.NetTxRate)
+	}
+	if !almostEqual(web.BlockReadRate, 4096) || web.BlockWriteRate != 0 {
+		t.Errorf("web block rates = %.2f/%.2f, want 4096/0", web.BlockReadRate, web.BlockWriteRate)
+	}
+}
+
+func TestGetContainerStatsAPIError(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "client version 1.99 is too new"}`, http.StatusBadRequest)
+	})
+	client := NewClient(startFakeDaemon(t, handler))
+
+	_, err := client.GetContainerStats(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("GetContainerStats() error = %v, want 400 error", err)
+	}
+}
+
+func TestGetContainerStatsMissingSocket(t *testing.T) {
+	client := NewClient(filepath.Join(t.TempDir(), "missing.sock"))
+
+	if _, err := client.GetContainerStats(context.Background()); err == nil {
+		t.Error("GetContainerStats() error = nil, want error")
+	}
+}
+
+func TestNewClientDefaultSocket(t *testing.T) {
+	if client := NewClient(""); client.socket != DefaultSocket {
+		t.Errorf("socket = %q, want %q", client.socket, DefaultSocket)
+	}
+}
@@ -0,0 +1,138 @@
+// Package exporter serves the latest metrics over HTTP in the Prometheus
+// text exposition format.
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/collector"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// contentType is the media type of the text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// PrometheusExporter implements render.Renderer by keeping the most recent
+// metrics and serving them to Prometheus on each scrape. Scrapes never
+// trigger a collection; the monitor keeps collecting on its own interval.
+type PrometheusExporter struct {
+	stats collector.StatsReporter // Collector counters, nil if not reported
+
+	mu             sync.Mutex
+	latest         *models.Metrics
+	scrapes        uint64
+	scrapeDuration time.Duration // Total time spent serving scrapes
+}
+
+// NewPrometheusExporter creates an exporter. If stats is not nil its
+// collection counters are exported alongside the metrics.
+func NewPrometheusExporter(stats collector.StatsReporter) *PrometheusExporter {
+	return &PrometheusExporter{stats: stats}
+}
+
+// Render stores metrics to be served by the next scrapes
+func (e *PrometheusExporter) Render(metrics *models.Metrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.latest = metrics
+	return nil
+}
+
+// Clear is a no-op for the exporter
+func (e *PrometheusExporter) Clear() error {
+	return nil
+}
+
+// Close is a no-op for the exporter
+func (e *PrometheusExporter) Close() error {
+	return nil
+}
+
+// Handler returns an HTTP handler serving the metrics at /metrics and a
+// pointer to them at /
+func (e *PrometheusExporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e)
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head><title>sysmon exporter</title></head>`+
+			`<body><h1>sysmon exporter</h1><p><a href="/metrics">Metrics</a></p></body></html>`)
+	})
+	return mux
+}
+
+// ServeHTTP writes the latest metrics in the text exposition format
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+
+	e.mu.Lock()
+	latest := e.latest
+	scrapes, scrapeDuration := e.scrapes, e.scrapeDuration
+	e.mu.Unlock()
+
+	// Render to a buffer first, so a failure cannot leave a truncated
+	// response that Prometheus would partially ingest
+	var buf bytes.Buffer
+	out := &expositionWriter{w: &buf}
+	if latest != nil {
+		writeMetrics(out, latest)
+	}
+	e.writeSelfMetrics(out, latest, scrapes, scrapeDuration)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+
+	e.mu.Lock()
+	e.scrapes++
+	e.scrapeDuration += time.Since(start)
+	e.mu.Unlock()
+}
+
+// writeSelfMetrics writes the exporter's and collector's own counters.
+// Scrape figures cover completed scrapes, excluding the current one.
+func (e *PrometheusExporter) writeSelfMetrics(out *expositionWriter, latest *models.Metrics,
+	scrapes uint64, scrapeDuration time.Duration) {
+
+	out.family("sysmon_scrape_duration_seconds", typeSummary, "Time spent serving scrapes of this endpoint.")
+	out.sample("sysmon_scrape_duration_seconds_sum", scrapeDuration.Seconds())
+	out.sample("sysmon_scrape_duration_seconds_count", float64(scrapes))
+
+	up := 0.0
+	if latest != nil {
+		up = 1
+		out.single("sysmon_last_collection_timestamp_seconds", typeGauge,
+			"Unix time of the most recent collection.", float64(latest.Timestamp.UnixNano())/1e9)
+	}
+	out.single("sysmon_up", typeGauge, "Whether a collection has completed since startup.", up)
+
+	if e.stats == nil {
+		return
+	}
+	stats := e.stats.Stats()
+
+	out.family("sysmon_collection_duration_seconds", typeSummary, "Time spent collecting metrics.")
+	out.sample("sysmon_collection_duration_seconds_sum", stats.TotalDuration.Seconds())
+	out.sample("sysmon_collection_duration_seconds_count", float64(stats.Collections))
+	out.single("sysmon_last_collection_duration_seconds", typeGauge,
+		"Time spent in the most recent collection.", stats.LastDuration.Seconds())
+
+	subsystems := make([]string, 0, len(stats.Errors))
+	for subsystem := range stats.Errors {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+
+	out.family("sysmon_collection_errors_total", typeCounter, "Failed collections by subsystem.")
+	for _, subsystem := range subsystems {
+		out.sample("sysmon_collection_errors_total", float64(stats.Errors[subsystem]), "subsystem", subsystem)
+	}
+}
@@ -0,0 +1,136 @@
+package exporter
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/collector"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// fakeStats reports fixed collection counters
+type fakeStats collector.CollectionStats
+
+func (f fakeStats) Stats() collector.CollectionStats {
+	return collector.CollectionStats(f)
+}
+
+// scrape fetches /metrics and returns the response body
+func scrape(t *testing.T, e *PrometheusExporter) string {
+	t.Helper()
+
+	server := httptest.NewServer(e.Handler())
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != contentType {
+		t.Errorf("Content-Type = %q, want %q", ct, contentType)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	return string(body)
+}
+
+func TestExporterServesLatestMetrics(t *testing.T) {
+	e := NewPrometheusExporter(fakeStats{
+		Collections:   4,
+		TotalDuration: 2 * time.Second,
+		LastDuration:  500 * time.Millisecond,
+		Errors:        map[string]uint64{"sensors": 3},
+	})
+
+	e.Render(&models.Metrics{
+		Timestamp: time.Unix(1700000000, 0),
+		CPU:       models.CPUStats{Overall: 42.5, PerCore: []float64{40, 45}},
+		Memory:    models.MemoryStats{Total: 8 << 30, Percent: 61.25},
+		Disk:      []models.DiskStats{{Mountpoint: "/", Percent: 73}},
+		Network:   []models.NetworkStats{{Interface: "eth0", BytesRecv: 1234}},
+	})
+
+	body := scrape(t, e)
+	for _, want := range []string{
+		"# TYPE sysmon_cpu_usage_percent gauge\nsysmon_cpu_usage_percent 42.5\n",
+		`sysmon_cpu_core_usage_percent{core="1"} 45`,
+		"sysmon_memory_total_bytes 8.589934592e+09\n",
+		"sysmon_memory_usage_percent 61.25\n",
+		`sysmon_filesystem_usage_percent{mountpoint="/"} 73`,
+		"# TYPE sysmon_network_receive_bytes_total counter\n",
+		`sysmon_network_receive_bytes_total{interface="eth0"} 1234`,
+		"sysmon_up 1\n",
+		"sysmon_last_collection_timestamp_seconds 1.7e+09\n",
+		"sysmon_collection_duration_seconds_sum 2\n",
+		"sysmon_collection_duration_seconds_count 4\n",
+		"sysmon_last_collection_duration_seconds 0.5\n",
+		`sysmon_collection_errors_total{subsystem="sensors"} 3`,
+		"sysmon_scrape_duration_seconds_count 0\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("response does not contain %q", want)
+		}
+	}
+
+	// Completed scrapes are counted by the next one
+	if body := scrape(t, e); !strings.Contains(body, "sysmon_scrape_duration_seconds_count 1\n") {
+		t.Errorf("second scrape does not count the first")
+	}
+}
+
+func TestExporterSensorsWithSameName(t *testing.T) {
+	e := NewPrometheusExporter(nil)
+	e.Render(&models.Metrics{
+		Timestamp: time.Unix(1700000000, 0),
+		Sensors: models.SensorStats{
+			Temperatures: []models.TemperatureSensor{
+				{Chip: "nvme", Device: "hwmon1", Label: "Composite", Current: 41},
+				{Chip: "nvme", Device: "hwmon2", Label: "Composite", Current: 68},
+			},
+			Fans: []models.FanSensor{
+				{Chip: "dell_smm", Device: "hwmon3", Label: "fan1", RPM: 1200},
+				{Chip: "dell_smm", Device: "hwmon4", Label: "fan1", RPM: 2400},
+			},
+		},
+	})
+
+	body := scrape(t, e)
+	for _, want := range []string{
+		`sysmon_temperature_celsius{chip="nvme",device="hwmon1",sensor="Composite"} 41`,
+		`sysmon_temperature_celsius{chip="nvme",device="hwmon2",sensor="Composite"} 68`,
+		`sysmon_fan_rpm{chip="dell_smm",device="hwmon3",sensor="fan1"} 1200`,
+		`sysmon_fan_rpm{chip="dell_smm",device="hwmon4",sensor="fan1"} 2400`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("response does not contain %q", want)
+		}
+	}
+}
+
+func TestExporterBeforeFirstCollection(t *testing.T) {
+	body := scrape(t, NewPrometheusExporter(nil))
+
+	if !strings.Contains(body, "sysmon_up 0\n") {
+		t.Errorf("response does not report sysmon_up 0:\n%s", body)
+	}
+	if strings.Contains(body, "sysmon_cpu_usage_percent") {
+		t.Errorf("response contains metrics before any collection")
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	var out strings.Builder
+	e := &expositionWriter{w: &out}
+	e.sample("m", 1, "sensor", "a\"b\\c\nd")
+
+	if want := `m{sensor="a\"b\\c\nd"} 1` + "\n"; out.String() != want {
+		t.Errorf("sample = %q, want %q", out.String(), want)
+	}
+}
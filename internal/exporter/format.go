@@ -0,0 +1,96 @@
+package exporter
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Metric types of the Prometheus text exposition format
+const (
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+	typeSummary = "summary"
+)
+
+// expositionWriter writes metric families in the Prometheus text format.
+// Samples of a family must be written straight after its header.
+type expositionWriter struct {
+	w   io.Writer
+	err error
+}
+
+// family writes the HELP and TYPE header of a metric family
+func (e *expositionWriter) family(name, typ, help string) {
+	e.write("# HELP " + name + " " + escapeHelp(help) + "\n")
+	e.write("# TYPE " + name + " " + typ + "\n")
+}
+
+// sample writes one sample. labels are name/value pairs.
+func (e *expositionWriter) sample(name string, value float64, labels ...string) {
+	var line strings.Builder
+	line.WriteString(name)
+
+	if len(labels) > 0 {
+		line.WriteString("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				line.WriteString(",")
+			}
+			line.WriteString(labels[i])
+			line.WriteString(`="`)
+			line.WriteString(escapeLabel(labels[i+1]))
+			line.WriteString(`"`)
+		}
+		line.WriteString("}")
+	}
+
+	line.WriteString(" ")
+	line.WriteString(formatValue(value))
+	line.WriteString("\n")
+	e.write(line.String())
+}
+
+// single writes a family with one unlabelled sample
+func (e *expositionWriter) single(name, typ, help string, value float64) {
+	e.family(name, typ, help)
+	e.sample(name, value)
+}
+
+// write keeps the first write error and skips writes after it
+func (e *expositionWriter) write(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = io.WriteString(e.w, s)
+}
+
+// formatValue formats a sample value, spelling special values the way
+// Prometheus expects
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+// escapeLabel escapes a label value
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// escapeHelp escapes HELP text
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
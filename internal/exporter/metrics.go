@@ -0,0 +1,270 @@
+package exporter
+
+import (
+	"strconv"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// writeMetrics writes a metrics snapshot. Metric names are part of the
+// exporter's interface: rename them only with a changelog entry, since
+// dashboards and alert rules depend on them. Cumulative kernel counters
+// are exported as counters for Prometheus to rate(); the rates sysmon
+// computes between its own samples are not exported.
+func writeMetrics(out *expositionWriter, m *models.Metrics) {
+	writeHost(out, m.Host)
+	writeCPU(out, m.CPU)
+	writeMemory(out, m.Memory)
+	writeFilesystems(out, m.Disk)
+	writeDiskIO(out, m.DiskIO)
+	writeNetwork(out, m.Network)
+	if m.Pressure.Available {
+		writePressure(out, m.Pressure)
+	}
+	if m.Activity.ContextSwitches > 0 {
+		writeActivity(out, m.Activity)
+	}
+	if m.Kernel.FilesMax > 0 {
+		writeKernelTables(out, m.Kernel)
+	}
+	writeSensors(out, m.Sensors)
+	if m.Sockets.TCPTotal > 0 || m.Sockets.UDPTotal > 0 {
+		writeSockets(out, m.Sockets)
+	}
+	if m.Cgroup.Available {
+		writeCgroup(out, m.Cgroup)
+	}
+	if len(m.Containers) > 0 {
+		writeContainers(out, m.Containers)
+	}
+}
+
+func writeHost(out *expositionWriter, host models.HostStats) {
+	out.family("sysmon_load_average", typeGauge, "System load average over 1, 5 and 15 minutes.")
+	out.sample("sysmon_load_average", host.Load1, "period", "1m")
+	out.sample("sysmon_load_average", host.Load5, "period", "5m")
+	out.sample("sysmon_load_average", host.Load15, "period", "15m")
+
+	out.single("sysmon_uptime_seconds", typeGauge, "Time since boot.", host.Uptime.Seconds())
+	out.single("sysmon_tasks_running", typeGauge, "Runnable tasks.", float64(host.RunningTasks))
+	out.single("sysmon_tasks", typeGauge, "Processes and threads.", float64(host.TotalTasks))
+}
+
+func writeCPU(out *expositionWriter, cpu models.CPUStats) {
+	out.single("sysmon_cpu_usage_percent", typeGauge, "Overall CPU usage (0-100).", cpu.Overall)
+
+	out.family("sysmon_cpu_core_usage_percent", typeGauge, "CPU usage per core (0-100).")
+	for i, percent := range cpu.PerCore {
+		out.sample("sysmon_cpu_core_usage_percent", percent, "core", strconv.Itoa(i))
+	}
+
+	b := cpu.Breakdown
+	out.family("sysmon_cpu_mode_percent", typeGauge, "Share of CPU time spent per mode (0-100).")
+	for _, mode := range []struct {
+		name  string
+		value float64
+	}{
+		{"user", b.User}, {"nice", b.Nice}, {"system", b.System}, {"idle", b.Idle},
+		{"iowait", b.IOWait}, {"irq", b.IRQ}, {"softirq", b.SoftIRQ}, {"steal", b.Steal},
+		{"guest", b.Guest}, {"guest_nice", b.GuestNice},
+	} {
+		out.sample("sysmon_cpu_mode_percent", mode.value, "mode", mode.name)
+	}
+
+	out.family("sysmon_cpu_core_frequency_mhz", typeGauge, "Current core clock speed.")
+	for i, freq := range cpu.PerCoreFrequency {
+		if freq.CurrentMHz > 0 {
+			out.sample("sysmon_cpu_core_frequency_mhz", freq.CurrentMHz, "core", strconv.Itoa(i))
+		}
+	}
+
+	out.family("sysmon_cpu_core_throttles_total", typeCounter, "Thermal throttle events per core since boot.")
+	for i, freq := range cpu.PerCoreFrequency {
+		out.sample("sysmon_cpu_core_throttles_total", float64(freq.ThrottleCount), "core", strconv.Itoa(i))
+	}
+}
+
+func writeMemory(out *expositionWriter, mem models.MemoryStats) {
+	out.single("sysmon_memory_total_bytes", typeGauge, "Total memory.", float64(mem.Total))
+	out.single("sysmon_memory_used_bytes", typeGauge, "Used memory.", float64(mem.Used))
+	out.single("sysmon_memory_available_bytes", typeGauge, "Memory available for new allocations.", float64(mem.Available))
+	out.single("sysmon_memory_usage_percent", typeGauge, "Memory usage (0-100).", mem.Percent)
+	out.single("sysmon_memory_buffers_bytes", typeGauge, "Block device buffers.", float64(mem.Buffers))
+	out.single("sysmon_memory_cached_bytes", typeGauge, "Page cache.", float64(mem.Cached))
+	out.single("sysmon_memory_dirty_bytes", typeGauge, "Memory waiting to be written back to disk.", float64(mem.Dirty))
+
+	out.single("sysmon_swap_total_bytes", typeGauge, "Total swap space.", float64(mem.Swap.Total))
+	out.single("sysmon_swap_used_bytes", typeGauge, "Used swap space.", float64(mem.Swap.Used))
+	out.single("sysmon_swap_usage_percent", typeGauge, "Swap usage (0-100).", mem.Swap.Percent)
+}
+
+func writeFilesystems(out *expositionWriter, disks []models.DiskStats) {
+	gauge := func(name, help string, value func(d models.DiskStats) float64) {
+		out.family(name, typeGauge, help)
+		for _, d := range disks {
+			out.sample(name, value(d), "mountpoint", d.Mountpoint)
+		}
+	}
+
+	gauge("sysmon_filesystem_size_bytes", "Filesystem size.",
+		func(d models.DiskStats) float64 { return float64(d.Total) })
+	gauge("sysmon_filesystem_used_bytes", "Used filesystem space.",
+		func(d models.DiskStats) float64 { return float64(d.Used) })
+	gauge("sysmon_filesystem_available_bytes", "Filesystem space available to unprivileged users.",
+		func(d models.DiskStats) float64 { return float64(d.Available) })
+	gauge("sysmon_filesystem_usage_percent", "Filesystem usage (0-100).",
+		func(d models.DiskStats) float64 { return d.Percent })
+	gauge("sysmon_filesystem_inodes", "Total inodes.",
+		func(d models.DiskStats) float64 { return float64(d.InodesTotal) })
+	gauge("sysmon_filesystem_inodes_used", "Used inodes.",
+		func(d models.DiskStats) float64 { return float64(d.InodesUsed) })
+	gauge("sysmon_filesystem_inode_usage_percent", "Inode usage (0-100).",
+		func(d models.DiskStats) float64 { return d.InodesPercent })
+}
+
+func writeDiskIO(out *expositionWriter, devices []models.DiskIOStats) {
+	counter := func(name, help string, value func(d models.DiskIOStats) float64) {
+		out.family(name, typeCounter, help)
+		for _, d := range devices {
+			out.sample(name, value(d), "device", d.Device)
+		}
+	}
+
+	counter("sysmon_disk_reads_completed_total", "Reads completed.",
+		func(d models.DiskIOStats) float64 { return float64(d.ReadsCompleted) })
+	counter("sysmon_disk_writes_completed_total", "Writes completed.",
+		func(d models.DiskIOStats) float64 { return float64(d.WritesCompleted) })
+	counter("sysmon_disk_read_bytes_total", "Bytes read.",
+		func(d models.DiskIOStats) float64 { return float64(d.ReadBytes) })
+	counter("sysmon_disk_written_bytes_total", "Bytes written.",
+		func(d models.DiskIOStats) float64 { return float64(d.WriteBytes) })
+	counter("sysmon_disk_io_time_seconds_total", "Time the device had I/O in flight.",
+		func(d models.DiskIOStats) float64 { return float64(d.IOTimeMs) / 1000 })
+}
+
+func writeNetwork(out *expositionWriter, networks []models.NetworkStats) {
+	counter := func(name, help string, value func(n models.NetworkStats) uint64) {
+		out.family(name, typeCounter, help)
+		for _, n := range networks {
+			out.sample(name, float64(value(n)), "interface", n.Interface)
+		}
+	}
+
+	counter("sysmon_network_receive_bytes_total", "Bytes received.",
+		func(n models.NetworkStats) uint64 { return n.BytesRecv })
+	counter("sysmon_network_transmit_bytes_total", "Bytes sent.",
+		func(n models.NetworkStats) uint64 { return n.BytesSent })
+	counter("sysmon_network_receive_packets_total", "Packets received.",
+		func(n models.NetworkStats) uint64 { return n.PacketsRecv })
+	counter("sysmon_network_transmit_packets_total", "Packets sent.",
+		func(n models.NetworkStats) uint64 { return n.PacketsSent })
+	counter("sysmon_network_receive_errors_total", "Receive errors.",
+		func(n models.NetworkStats) uint64 { return n.ErrorsRecv })
+	counter("sysmon_network_transmit_errors_total", "Transmit errors.",
+		func(n models.NetworkStats) uint64 { return n.ErrorsSent })
+	counter("sysmon_network_receive_drops_total", "Packets dropped on receive.",
+		func(n models.NetworkStats) uint64 { return n.DropsRecv })
+	counter("sysmon_network_transmit_drops_total", "Packets dropped on transmit.",
+		func(n models.NetworkStats) uint64 { return n.DropsSent })
+}
+
+func writePressure(out *expositionWriter, psi models.PressureStats) {
+	resources := []struct {
+		name string
+		res  models.PressureResource
+	}{
+		{"cpu", psi.CPU}, {"memory", psi.Memory}, {"io", psi.IO},
+	}
+
+	out.family("sysmon_pressure_avg10_percent", typeGauge, "Share of time tasks stalled on a resource over the last 10 seconds (0-100).")
+	for _, r := range resources {
+		out.sample("sysmon_pressure_avg10_percent", r.res.Some.Avg10, "resource", r.name, "kind", "some")
+		out.sample("sysmon_pressure_avg10_percent", r.res.Full.Avg10, "resource", r.name, "kind", "full")
+	}
+
+	out.family("sysmon_pressure_stalled_seconds_total", typeCounter, "Time tasks stalled on a resource since boot.")
+	for _, r := range resources {
+		out.sample("sysmon_pressure_stalled_seconds_total", r.res.Some.Total.Seconds(), "resource", r.name, "kind", "some")
+		out.sample("sysmon_pressure_stalled_seconds_total", r.res.Full.Total.Seconds(), "resource", r.name, "kind", "full")
+	}
+}
+
+func writeActivity(out *expositionWriter, a models.ActivityStats) {
+	out.single("sysmon_context_switches_total", typeCounter, "Context switches since boot.", float64(a.ContextSwitches))
+	out.single("sysmon_interrupts_total", typeCounter, "Interrupts serviced since boot.", float64(a.Interrupts))
+	out.single("sysmon_forks_total", typeCounter, "Processes and threads created since boot.", float64(a.Forks))
+	out.single("sysmon_tasks_blocked", typeGauge, "Tasks blocked on I/O.", float64(a.ProcsBlocked))
+	out.single("sysmon_page_faults_total", typeCounter, "Page faults since boot.", float64(a.PageFaults))
+	out.single("sysmon_major_page_faults_total", typeCounter, "Major page faults since boot.", float64(a.MajorPageFaults))
+	out.single("sysmon_swapped_in_pages_total", typeCounter, "Pages swapped in since boot.", float64(a.SwappedIn))
+	out.single("sysmon_swapped_out_pages_total", typeCounter, "Pages swapped out since boot.", float64(a.SwappedOut))
+	out.single("sysmon_oom_kills_total", typeCounter, "Processes killed by the OOM killer since boot.", float64(a.OOMKills))
+}
+
+func writeKernelTables(out *expositionWriter, k models.KernelTableStats) {
+	out.single("sysmon_file_handles_allocated", typeGauge, "Allocated file handles.", float64(k.FilesAllocated))
+	out.single("sysmon_file_handles_max", typeGauge, "Maximum file handles (fs.file-max).", float64(k.FilesMax))
+	out.single("sysmon_file_handles_usage_percent", typeGauge, "File handle usage (0-100).", k.FilesPercent)
+	out.single("sysmon_pid_usage_percent", typeGauge, "Tasks relative to kernel.pid_max (0-100).", k.PIDPercent)
+	out.single("sysmon_thread_usage_percent", typeGauge, "Tasks relative to kernel.threads-max (0-100).", k.ThreadPercent)
+}
+
+func writeSensors(out *expositionWriter, sensors models.SensorStats) {
+	// Chip names repeat across identical devices, so the device label
+	// keeps their series apart
+	out.family("sysmon_temperature_celsius", typeGauge, "Hardware temperature readings.")
+	for _, t := range sensors.Temperatures {
+		out.sample("sysmon_temperature_celsius", t.Current, "chip", t.Chip, "device", t.Device, "sensor", t.Label)
+	}
+
+	out.family("sysmon_fan_rpm", typeGauge, "Fan speeds.")
+	for _, f := range sensors.Fans {
+		out.sample("sysmon_fan_rpm", float64(f.RPM), "chip", f.Chip, "device", f.Device, "sensor", f.Label)
+	}
+}
+
+func writeSockets(out *expositionWriter, s models.SocketStats) {
+	out.family("sysmon_tcp_sockets", typeGauge, "TCP sockets by state.")
+	for _, state := range models.TCPStateNames {
+		out.sample("sysmon_tcp_sockets", float64(s.TCPStates[state]), "state", state)
+	}
+	out.single("sysmon_udp_sockets", typeGauge, "UDP sockets.", float64(s.UDPTotal))
+	out.single("sysmon_ephemeral_ports_usage_percent", typeGauge,
+		"Local ports in ip_local_port_range used by TCP connections (0-100).", s.EphemeralPortsPercent)
+}
+
+func writeCgroup(out *expositionWriter, cg models.CgroupStats) {
+	out.single("sysmon_cgroup_cpu_usage_seconds_total", typeCounter, "CPU time consumed by the cgroup.", cg.CPUUsage.Seconds())
+	out.single("sysmon_cgroup_cpu_throttled_periods_total", typeCounter, "Periods in which the cgroup was throttled.", float64(cg.NrThrottled))
+	out.single("sysmon_cgroup_cpu_limit_cores", typeGauge, "CPU limit in cores, 0 if unlimited.", cg.CPULimit)
+	out.single("sysmon_cgroup_memory_bytes", typeGauge, "Memory used by the cgroup.", float64(cg.MemoryCurrent))
+	out.single("sysmon_cgroup_memory_limit_bytes", typeGauge, "Memory limit, 0 if unlimited.", float64(cg.MemoryMax))
+	out.single("sysmon_cgroup_oom_kills_total", typeCounter, "Processes in the cgroup killed by the OOM killer.", float64(cg.MemoryEvents.OOMKill))
+}
+
+func writeContainers(out *expositionWriter, containers []models.ContainerStats) {
+	labels := func(c models.ContainerStats) []string {
+		return []string{"id", c.ID, "name", c.Name, "image", c.Image}
+	}
+
+	out.family("sysmon_container_cpu_seconds_total", typeCounter, "CPU time consumed by the container.")
+	for _, c := range containers {
+		out.sample("sysmon_container_cpu_seconds_total", c.CPUTime.Seconds(), labels(c)...)
+	}
+	out.family("sysmon_container_memory_bytes", typeGauge, "Memory used by the container, excluding inactive page cache.")
+	for _, c := range containers {
+		out.sample("sysmon_container_memory_bytes", float64(c.MemoryUsage), labels(c)...)
+	}
+	out.family("sysmon_container_memory_limit_bytes", typeGauge, "Container memory limit.")
+	for _, c := range containers {
+		out.sample("sysmon_container_memory_limit_bytes", float64(c.MemoryLimit), labels(c)...)
+	}
+	out.family("sysmon_container_network_receive_bytes_total", typeCounter, "Bytes received by the container.")
+	for _, c := range containers {
+		out.sample("sysmon_container_network_receive_bytes_total", float64(c.NetRxBytes), labels(c)...)
+	}
+	out.family("sysmon_container_network_transmit_bytes_total", typeCounter, "Bytes sent by the container.")
+	for _, c := range containers {
+		out.sample("sysmon_container_network_transmit_bytes_total", float64(c.NetTxBytes), labels(c)...)
+	}
+}
@@ -0,0 +1,118 @@
+package history
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// History records a window of recent samples for each graphed metric.
+// It is safe for concurrent use.
+type History struct {
+	mu     sync.RWMutex
+	size   int
+	series map[string]*Ring
+}
+
+// New creates a history keeping the last size samples of each metric
+func New(size int) *History {
+	return &History{
+		size:   size,
+		series: make(map[string]*Ring),
+	}
+}
+
+// Size returns the number of samples kept per metric
+func (h *History) Size() int {
+	return h.size
+}
+
+// Record appends the graphed values of a metrics snapshot. Series for
+// cores, interfaces and filesystems that have disappeared are dropped.
+func (h *History) Record(metrics *models.Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seen := make(map[string]bool)
+	push := func(key string, v float64) {
+		ring, ok := h.series[key]
+		if !ok {
+			ring = NewRing(h.size)
+			h.series[key] = ring
+		}
+		ring.Push(v)
+		seen[key] = true
+	}
+
+	push(cpuKey, metrics.CPU.Overall)
+	for i, percent := range metrics.CPU.PerCore {
+		push(coreKey(i), percent)
+	}
+	push(memoryKey, metrics.Memory.Percent)
+	for _, net := range metrics.Network {
+		push(netRxKey(net.Interface), net.RecvRate)
+		push(netTxKey(net.Interface), net.SendRate)
+	}
+	for _, disk := range metrics.Disk {
+		push(diskKey(disk.Mountpoint), disk.Percent)
+	}
+
+	for key := range h.series {
+		if !seen[key] {
+			delete(h.series, key)
+		}
+	}
+}
+
+// CPU returns recent overall CPU usage percentages, oldest first
+func (h *History) CPU() []float64 {
+	return h.values(cpuKey)
+}
+
+// Core returns recent usage percentages of the given core
+func (h *History) Core(i int) []float64 {
+	return h.values(coreKey(i))
+}
+
+// Memory returns recent memory usage percentages
+func (h *History) Memory() []float64 {
+	return h.values(memoryKey)
+}
+
+// NetRx returns recent receive rates of the interface in bytes per second
+func (h *History) NetRx(iface string) []float64 {
+	return h.values(netRxKey(iface))
+}
+
+// NetTx returns recent send rates of the interface in bytes per second
+func (h *History) NetTx(iface string) []float64 {
+	return h.values(netTxKey(iface))
+}
+
+// Disk returns recent usage percentages of the filesystem
+func (h *History) Disk(mountpoint string) []float64 {
+	return h.values(diskKey(mountpoint))
+}
+
+// values returns a copy of the samples of a series
+func (h *History) values(key string) []float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if ring, ok := h.series[key]; ok {
+		return ring.Values()
+	}
+	return nil
+}
+
+// Series keys
+const (
+	cpuKey    = "cpu"
+	memoryKey = "memory"
+)
+
+func coreKey(i int) string             { return "cpu/" + strconv.Itoa(i) }
+func netRxKey(iface string) string     { return "net/" + iface + "/rx" }
+func netTxKey(iface string) string     { return "net/" + iface + "/tx" }
+func diskKey(mountpoint string) string { return "disk/" + mountpoint }
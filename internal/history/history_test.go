@@ -0,0 +1,74 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+func TestRingOverwritesOldest(t *testing.T) {
+	r := NewRing(3)
+	if got := r.Values(); len(got) != 0 {
+		t.Fatalf("empty ring Values() = %v, want none", got)
+	}
+
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		r.Push(v)
+	}
+	if r.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", r.Len())
+	}
+	if got, want := r.Values(), []float64{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestRingZeroSize(t *testing.T) {
+	r := NewRing(0)
+	r.Push(1)
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", r.Len())
+	}
+}
+
+func TestHistoryRecord(t *testing.T) {
+	h := New(2)
+
+	sample := func(cpu float64, ifaces ...string) *models.Metrics {
+		m := &models.Metrics{
+			CPU:    models.CPUStats{Overall: cpu, PerCore: []float64{cpu / 2}},
+			Memory: models.MemoryStats{Percent: 40},
+			Disk:   []models.DiskStats{{Mountpoint: "/", Percent: 70}},
+		}
+		for _, iface := range ifaces {
+			m.Network = append(m.Network, models.NetworkStats{Interface: iface, RecvRate: cpu, SendRate: 1})
+		}
+		return m
+	}
+
+	h.Record(sample(10, "eth0", "wlan0"))
+	h.Record(sample(20, "eth0"))
+	h.Record(sample(30, "eth0"))
+
+	if got, want := h.CPU(), []float64{20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CPU() = %v, want %v", got, want)
+	}
+	if got, want := h.Core(0), []float64{10, 15}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Core(0) = %v, want %v", got, want)
+	}
+	if got, want := h.Memory(), []float64{40, 40}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Memory() = %v, want %v", got, want)
+	}
+	if got, want := h.Disk("/"), []float64{70, 70}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Disk(/) = %v, want %v", got, want)
+	}
+	if got, want := h.NetRx("eth0"), []float64{20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NetRx(eth0) = %v, want %v", got, want)
+	}
+
+	// Interfaces that disappear lose their history
+	if got := h.NetTx("wlan0"); got != nil {
+		t.Errorf("NetTx(wlan0) = %v, want nil after the interface disappeared", got)
+	}
+}
@@ -0,0 +1,44 @@
+// Package history keeps a bounded window of recent metric samples for
+// drawing trends.
+package history
+
+// Ring is a fixed-capacity buffer of samples that overwrites the oldest
+// sample once full
+type Ring struct {
+	values []float64
+	start  int // Index of the oldest sample
+	count  int
+}
+
+// NewRing creates a ring holding up to size samples
+func NewRing(size int) *Ring {
+	return &Ring{values: make([]float64, size)}
+}
+
+// Push appends a sample, dropping the oldest if the ring is full
+func (r *Ring) Push(v float64) {
+	if len(r.values) == 0 {
+		return
+	}
+	if r.count < len(r.values) {
+		r.values[(r.start+r.count)%len(r.values)] = v
+		r.count++
+		return
+	}
+	r.values[r.start] = v
+	r.start = (r.start + 1) % len(r.values)
+}
+
+// Len returns the number of samples held
+func (r *Ring) Len() int {
+	return r.count
+}
+
+// Values returns a copy of the samples, oldest first
+func (r *Ring) Values() []float64 {
+	values := make([]float64, r.count)
+	for i := range values {
+		values[i] = r.values[(r.start+i)%len(r.values)]
+	}
+	return values
+}
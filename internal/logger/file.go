@@ -3,14 +3,19 @@ package logger
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/sysmon/system-monitor-cli/internal/alert"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 )
 
-// FileLogger implements Logger for file-based logging
+// FileLogger implements Logger for file-based logging. It is safe for
+// concurrent use, since notifiers log errors from their own goroutines.
 type FileLogger struct {
+	mu      sync.Mutex // Serializes writes so entries don't interleave
 	file    *os.File
 	encoder *json.Encoder
 }
@@ -39,9 +44,27 @@ func (l *FileLogger) LogMetrics(metrics *models.Metrics) error {
 		Metrics:   metrics,
 	}
 
-	if err := l.encoder.Encode(entry); err != nil {
-		// Log to stderr but don't fail
-		fmt.Fprintf(os.Stderr, "Warning: failed to write to log file: %v\n", err)
+	if err := l.encode(entry); err != nil {
+		// Log the failure but don't fail
+		log.Printf("Warning: failed to write to log file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// LogAlert writes an alert state transition to the log file
+func (l *FileLogger) LogAlert(event alert.Event) error {
+	entry := struct {
+		Timestamp string      `json:"timestamp"`
+		Alert     alert.Event `json:"alert"`
+	}{
+		Timestamp: event.Time.Format(time.RFC3339),
+		Alert:     event,
+	}
+
+	if err := l.encode(entry); err != nil {
+		log.Printf("Warning: failed to write alert to log file: %v", err)
 		return err
 	}
 
@@ -58,16 +81,25 @@ func (l *FileLogger) LogError(err error) error {
 		Error:     err.Error(),
 	}
 
-	if encodeErr := l.encoder.Encode(entry); encodeErr != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to write error to log file: %v\n", encodeErr)
+	if encodeErr := l.encode(entry); encodeErr != nil {
+		log.Printf("Warning: failed to write error to log file: %v", encodeErr)
 		return encodeErr
 	}
 
 	return nil
 }
 
+// encode writes one entry to the log file
+func (l *FileLogger) encode(entry interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.encoder.Encode(entry)
+}
+
 // Close flushes and closes the log file
 func (l *FileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	if l.file != nil {
 		return l.file.Close()
 	}
@@ -1,12 +1,18 @@
 package logger
 
-import "github.com/sysmon/system-monitor-cli/internal/models"
+import (
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
 
 // Logger defines the interface for logging metrics and errors
 type Logger interface {
 	// LogMetrics writes metrics to the log destination
 	LogMetrics(metrics *models.Metrics) error
 
+	// LogAlert writes an alert state transition to the log destination
+	LogAlert(event alert.Event) error
+
 	// LogError writes an error to the log destination
 	LogError(err error) error
 
@@ -5,16 +5,70 @@ import "time"
 // Metrics represents a complete snapshot of system metrics at a point in time
 type Metrics struct {
 	Timestamp time.Time
+	Host      HostStats
 	CPU       CPUStats
 	Memory    MemoryStats
 	Disk      []DiskStats
+	DiskIO    []DiskIOStats
 	Network   []NetworkStats
+	Processes []ProcessStats
+	Pressure  PressureStats
+	Cgroup    CgroupStats
+	Sensors   SensorStats
+	Sockets   SocketStats
+	Activity  ActivityStats
+	Kernel    KernelTableStats
+
+	Containers []ContainerStats
+}
+
+// HostStats represents a summary of overall host activity
+type HostStats struct {
+	Load1        float64       // 1-minute load average
+	Load5        float64       // 5-minute load average
+	Load15       float64       // 15-minute load average
+	Uptime       time.Duration // Time since boot
+	RunningTasks uint64        // Number of currently runnable tasks
+	TotalTasks   uint64        // Total number of tasks (processes and threads)
 }
 
 // CPUStats represents CPU usage statistics
 type CPUStats struct {
 	Overall float64   // Overall CPU usage percentage (0-100)
 	PerCore []float64 // Per-core usage percentages (0-100)
+
+	Breakdown        CPUBreakdown   // Overall time spent per mode
+	PerCoreBreakdown []CPUBreakdown // Per-core time spent per mode
+
+	PerCoreFrequency []CoreFrequency // Per-core clock speed and throttling
+}
+
+// CoreFrequency represents the clock speed and thermal throttling of a core
+type CoreFrequency struct {
+	CurrentMHz float64 // Current frequency (0 if cpufreq is unavailable)
+	MinMHz     float64 // Minimum scaling frequency
+	MaxMHz     float64 // Maximum scaling frequency
+
+	ThrottleCount        uint64  // Core thermal throttle events since boot
+	PackageThrottleCount uint64  // Package thermal throttle events since boot
+	ThrottleRate         float64 // Core throttle events per second
+	PackageThrottleRate  float64 // Package throttle events per second
+}
+
+// CPUBreakdown represents the share of CPU time spent in each mode.
+// All values are percentages (0-100) of the elapsed time between samples.
+// Guest and GuestNice are already accounted for in User and Nice.
+type CPUBreakdown struct {
+	User      float64
+	Nice      float64
+	System    float64
+	Idle      float64
+	IOWait    float64
+	IRQ       float64
+	SoftIRQ   float64
+	Steal     float64
+	Guest     float64
+	GuestNice float64
 }
 
 // MemoryStats represents memory usage statistics
@@ -23,6 +77,29 @@ type MemoryStats struct {
 	Used      uint64  // Used memory in bytes
 	Available uint64  // Available memory in bytes
 	Percent   float64 // Usage percentage (0-100)
+
+	// Detailed breakdown
+	Buffers           uint64 // Block device buffers in bytes
+	Cached            uint64 // Page cache in bytes
+	Dirty             uint64 // Memory waiting to be written back to disk in bytes
+	Shmem             uint64 // Shared memory and tmpfs in bytes
+	SlabReclaimable   uint64 // Reclaimable kernel slab in bytes
+	SlabUnreclaimable uint64 // Unreclaimable kernel slab in bytes
+
+	// Huge pages
+	HugePagesTotal uint64 // Size of the huge page pool (pages)
+	HugePagesFree  uint64 // Unallocated huge pages (pages)
+	HugePageSize   uint64 // Size of a single huge page in bytes
+
+	Swap SwapStats
+}
+
+// SwapStats represents swap space usage statistics
+type SwapStats struct {
+	Total   uint64  // Total swap space in bytes
+	Used    uint64  // Used swap space in bytes
+	Free    uint64  // Free swap space in bytes
+	Percent float64 // Usage percentage (0-100)
 }
 
 // DiskStats represents disk usage statistics for a filesystem
@@ -32,6 +109,30 @@ type DiskStats struct {
 	Used       uint64  // Used space in bytes
 	Available  uint64  // Available space in bytes
 	Percent    float64 // Usage percentage (0-100)
+
+	InodesTotal   uint64  // Total inodes
+	InodesUsed    uint64  // Used inodes
+	InodesFree    uint64  // Free inodes
+	InodesPercent float64 // Inode usage percentage (0-100)
+}
+
+// DiskIOStats represents I/O statistics for a block device
+type DiskIOStats struct {
+	Device          string
+	ReadsCompleted  uint64 // Total reads completed
+	WritesCompleted uint64 // Total writes completed
+	ReadBytes       uint64 // Total bytes read
+	WriteBytes      uint64 // Total bytes written
+	ReadTimeMs      uint64 // Total time spent reading (milliseconds)
+	WriteTimeMs     uint64 // Total time spent writing (milliseconds)
+	IOTimeMs        uint64 // Total time the device had I/O in flight (milliseconds)
+
+	ReadRate  float64 // Bytes read per second
+	WriteRate float64 // Bytes written per second
+	ReadIOPS  float64 // Reads completed per second
+	WriteIOPS float64 // Writes completed per second
+	AwaitMs   float64 // Average time per completed request (milliseconds)
+	Util      float64 // Percentage of time the device was busy (0-100)
 }
 
 // NetworkStats represents network I/O statistics for an interface
@@ -41,6 +142,285 @@ type NetworkStats struct {
 	BytesRecv uint64  // Total bytes received
 	SendRate  float64 // Bytes per second
 	RecvRate  float64 // Bytes per second
+
+	// Receive counters
+	PacketsRecv    uint64 // Total packets received
+	ErrorsRecv     uint64 // Total receive errors
+	DropsRecv      uint64 // Total packets dropped on receive
+	FifoRecv       uint64 // Total receive FIFO buffer errors
+	FrameRecv      uint64 // Total packet framing errors
+	CompressedRecv uint64 // Total compressed packets received
+	MulticastRecv  uint64 // Total multicast frames received
+
+	// Transmit counters
+	PacketsSent    uint64 // Total packets sent
+	ErrorsSent     uint64 // Total transmit errors
+	DropsSent      uint64 // Total packets dropped on transmit
+	FifoSent       uint64 // Total transmit FIFO buffer errors
+	Collisions     uint64 // Total collisions detected
+	CarrierSent    uint64 // Total carrier losses
+	CompressedSent uint64 // Total compressed packets sent
+
+	// Per-second rates
+	PacketRecvRate float64 // Packets received per second
+	PacketSendRate float64 // Packets sent per second
+	ErrorRecvRate  float64 // Receive errors per second
+	ErrorSendRate  float64 // Transmit errors per second
+	DropRecvRate   float64 // Receive drops per second
+	DropSendRate   float64 // Transmit drops per second
+
+	// Link fault rates. Drivers usually count these in the error totals
+	// too, so they break errors down rather than add to them.
+	FifoRate    float64 // Receive and transmit FIFO errors per second
+	FrameRate   float64 // Framing errors per second
+	CarrierRate float64 // Carrier losses per second
+}
+
+// ErrorRate returns the combined receive and transmit error rate
+func (n NetworkStats) ErrorRate() float64 {
+	return n.ErrorRecvRate + n.ErrorSendRate
+}
+
+// DropRate returns the combined receive and transmit drop rate
+func (n NetworkStats) DropRate() float64 {
+	return n.DropRecvRate + n.DropSendRate
+}
+
+// PressureStats represents Pressure Stall Information (PSI) for CPU,
+// memory and I/O
+type PressureStats struct {
+	Available bool // False when the kernel does not support PSI
+	CPU       PressureResource
+	Memory    PressureResource
+	IO        PressureResource
+}
+
+// PressureResource holds the "some" and "full" stall lines for one resource.
+// "some" is time at least one task was stalled, "full" is time all non-idle
+// tasks were stalled at once.
+type PressureResource struct {
+	Some PressureLine
+	Full PressureLine
+}
+
+// PressureLine represents one line of a /proc/pressure file
+type PressureLine struct {
+	Avg10  float64       // Percentage of time stalled over the last 10 seconds
+	Avg60  float64       // Percentage of time stalled over the last 60 seconds
+	Avg300 float64       // Percentage of time stalled over the last 300 seconds
+	Total  time.Duration // Total stall time since boot
+}
+
+// CgroupStats represents resource accounting for a cgroup v2 group
+type CgroupStats struct {
+	Available bool   // False when cgroup v2 is not mounted
+	Path      string // Cgroup path relative to the cgroup2 mount
+
+	// CPU (cpu.stat, cpu.max)
+	CPUUsage      time.Duration // Total CPU time consumed
+	CPUUser       time.Duration // CPU time in user mode
+	CPUSystem     time.Duration // CPU time in kernel mode
+	NrPeriods     uint64        // Enforcement periods elapsed
+	NrThrottled   uint64        // Periods in which the group was throttled
+	ThrottledTime time.Duration // Total time throttled
+	CPULimit      float64       // CPU limit in cores (0 = unlimited)
+	CPUPercent    float64       // CPU usage relative to the limit, or to all cores if unlimited (0-100)
+
+	// Memory (memory.current, memory.max, memory.events)
+	MemoryCurrent uint64  // Current memory usage in bytes
+	MemoryMax     uint64  // Memory limit in bytes (0 = unlimited)
+	MemoryPercent float64 // Usage relative to the limit (0-100, 0 if unlimited)
+	MemoryEvents  CgroupMemoryEvents
+
+	// I/O (io.stat)
+	IO []CgroupIOStats
+}
+
+// CgroupMemoryEvents counts memory limit events from memory.events
+type CgroupMemoryEvents struct {
+	Low     uint64 // Reclaimed despite being under memory.low
+	High    uint64 // Throttled for exceeding memory.high
+	Max     uint64 // Reached memory.max
+	OOM     uint64 // Allocations that hit the limit and triggered the OOM path
+	OOMKill uint64 // Processes killed by the OOM killer
+}
+
+// CgroupIOStats represents per-device I/O counters from io.stat
+type CgroupIOStats struct {
+	Device     string // Device number as major:minor
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadIOs    uint64
+	WriteIOs   uint64
+}
+
+// SensorStats represents hardware temperature and fan readings
+type SensorStats struct {
+	Temperatures []TemperatureSensor
+	Fans         []FanSensor
+}
+
+// TemperatureSensor represents a single temperature reading
+type TemperatureSensor struct {
+	Chip     string  // Driver name (e.g. coretemp) or thermal zone type
+	Device   string  // hwmon device (e.g. hwmon3) or thermal zone, unique where Chip is not
+	Label    string  // Sensor label (e.g. "Core 0"), or the channel name if unlabelled
+	Current  float64 // Current temperature in degrees Celsius
+	High     float64 // Hardware high threshold in degrees Celsius (0 if unknown)
+	Critical float64 // Hardware critical threshold in degrees Celsius (0 if unknown)
+}
+
+// Name returns the sensor's "chip/label" identifier. Identical chips,
+// such as two NVMe drives, share it.
+func (s TemperatureSensor) Name() string {
+	return s.Chip + "/" + s.Label
+}
+
+// ID returns an identifier unique on the host, the name followed by the
+// device, e.g. "nvme/Composite (hwmon3)". Thermal zones are named after
+// their device already.
+func (s TemperatureSensor) ID() string {
+	if s.Device == "" || s.Device == s.Label {
+		return s.Name()
+	}
+	return s.Name() + " (" + s.Device + ")"
+}
+
+// FanSensor represents a single fan speed reading
+type FanSensor struct {
+	Chip   string // Driver name
+	Device string // hwmon device (e.g. hwmon3), unique where Chip is not
+	Label  string // Fan label, or the channel name if unlabelled
+	RPM    uint64 // Current speed in revolutions per minute
+	Min    uint64 // Minimum speed in RPM (0 if unknown)
+}
+
+// ActivityStats represents vmstat-style kernel activity counters.
+// Counters are cumulative since boot; rates are per second over the
+// interval since the previous sample and zero on the first sample.
+type ActivityStats struct {
+	// From /proc/stat
+	ContextSwitches uint64 // Context switches since boot
+	Interrupts      uint64 // Interrupts serviced since boot
+	Forks           uint64 // Processes and threads created since boot
+	ProcsRunning    uint64 // Tasks currently runnable
+	ProcsBlocked    uint64 // Tasks currently blocked on I/O
+
+	// From /proc/vmstat
+	PageFaults      uint64 // Page faults since boot (minor and major)
+	MajorPageFaults uint64 // Major page faults (requiring I/O) since boot
+	PagedIn         uint64 // Data paged in from block devices since boot in KB
+	PagedOut        uint64 // Data paged out to block devices since boot in KB
+	SwappedIn       uint64 // Pages swapped in since boot
+	SwappedOut      uint64 // Pages swapped out since boot
+	OOMKills        uint64 // Processes killed by the OOM killer since boot
+
+	ContextSwitchRate  float64
+	InterruptRate      float64
+	ForkRate           float64
+	PageFaultRate      float64
+	MajorPageFaultRate float64
+	PageInRate         float64 // KB per second
+	PageOutRate        float64 // KB per second
+	SwapInRate         float64 // Pages per second
+	SwapOutRate        float64 // Pages per second
+
+	NewOOMKills uint64 // OOM kills since the previous sample
+}
+
+// KernelTableStats represents usage of system-wide kernel tables whose
+// exhaustion causes failures such as "too many open files" or fork errors
+type KernelTableStats struct {
+	FilesAllocated uint64  // Allocated file handles
+	FilesMax       uint64  // Maximum file handles (fs.file-max)
+	FilesPercent   float64 // Allocated file handles relative to the maximum (0-100)
+
+	InodesAllocated uint64 // Inodes allocated in the inode cache
+	InodesFree      uint64 // Allocated inodes that are unused
+
+	Tasks         uint64  // Processes and threads, each holding a PID
+	PIDMax        uint64  // Highest PID value plus one (kernel.pid_max)
+	ThreadsMax    uint64  // Maximum number of threads (kernel.threads-max)
+	PIDPercent    float64 // Tasks relative to pid_max (0-100)
+	ThreadPercent float64 // Tasks relative to threads-max (0-100)
+}
+
+// SocketStats represents a summary of TCP and UDP sockets
+type SocketStats struct {
+	TCPStates map[string]uint64 // Number of TCP sockets per state (ESTABLISHED, TIME_WAIT, ...)
+	TCPTotal  uint64            // Total TCP sockets (IPv4 and IPv6)
+	UDPTotal  uint64            // Total UDP sockets (IPv4 and IPv6)
+	Listening []ListeningSocket // Listening TCP and bound UDP sockets
+
+	// From /proc/net/sockstat
+	SocketsUsed uint64 // Sockets of all protocols in use
+	TCPInUse    uint64 // IPv4 TCP sockets in use
+	TCPOrphans  uint64 // TCP sockets no longer attached to a process
+	TCPTimeWait uint64 // TCP sockets in TIME_WAIT
+	TCPAlloc    uint64 // Allocated TCP sockets
+	TCPMemory   uint64 // Memory used by TCP buffers in bytes
+	UDPInUse    uint64 // IPv4 UDP sockets in use
+	UDPMemory   uint64 // Memory used by UDP buffers in bytes
+
+	// Local ports in use by connected TCP sockets within ip_local_port_range
+	EphemeralPortsUsed    uint64
+	EphemeralPortsTotal   uint64
+	EphemeralPortsPercent float64
+}
+
+// ListeningSocket represents a socket accepting connections or datagrams
+type ListeningSocket struct {
+	Protocol string // tcp, tcp6, udp or udp6
+	Address  string // Local IP address
+	Port     uint16 // Local port
+}
+
+// TCPStateNames lists TCP states in the order the kernel numbers them
+var TCPStateNames = []string{
+	"ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2", "TIME_WAIT",
+	"CLOSE", "CLOSE_WAIT", "LAST_ACK", "LISTEN", "CLOSING", "NEW_SYN_RECV",
+}
+
+// ContainerStats represents resource usage of a running container
+type ContainerStats struct {
+	ID     string // Short (12 character) container ID
+	Name   string // Container name without the leading slash
+	Image  string // Image the container was created from
+	Status string // Human-readable status, e.g. "Up 3 hours"
+
+	CPUTime    time.Duration // Total CPU time consumed
+	CPUPercent float64       // CPU usage since the previous sample (100 = one full core)
+
+	MemoryUsage   uint64  // Memory in use in bytes, excluding inactive page cache
+	MemoryLimit   uint64  // Memory limit in bytes (host memory if unlimited)
+	MemoryPercent float64 // Usage relative to the limit (0-100)
+
+	NetRxBytes     uint64  // Bytes received across all networks
+	NetTxBytes     uint64  // Bytes sent across all networks
+	NetRxRate      float64 // Bytes received per second
+	NetTxRate      float64 // Bytes sent per second
+	BlockRead      uint64  // Bytes read from block devices
+	BlockWrite     uint64  // Bytes written to block devices
+	BlockReadRate  float64 // Bytes read per second
+	BlockWriteRate float64 // Bytes written per second
+
+	PIDs uint64 // Number of processes and threads
+}
+
+// ProcessStats represents resource usage of a single process
+type ProcessStats struct {
+	PID     int
+	PPID    int
+	Name    string        // Executable name from /proc/[pid]/stat
+	Command string        // Full command line
+	User    string        // Owner user name (or numeric UID if unknown)
+	State   string        // Single-letter scheduler state (R, S, D, Z, ...)
+	Threads uint64        // Number of threads
+	RSS     uint64        // Resident set size in bytes
+	CPUTime time.Duration // Total CPU time consumed (user + system)
+
+	CPUPercent float64 // CPU usage since the previous sample (100 = one full core)
+	MemPercent float64 // RSS as a percentage of total memory (0-100)
 }
 
 // CalculatePercentage calculates percentage from used and total values
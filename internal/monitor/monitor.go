@@ -2,22 +2,48 @@ package monitor
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log"
 	"sync"
+	"time"
 
+	"github.com/sysmon/system-monitor-cli/internal/alert"
 	"github.com/sysmon/system-monitor-cli/internal/collector"
 	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/history"
 	"github.com/sysmon/system-monitor-cli/internal/logger"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 	"github.com/sysmon/system-monitor-cli/internal/render"
+	"github.com/sysmon/system-monitor-cli/internal/tui"
 )
 
+// processSorter is implemented by collectors whose process table order
+// can be changed between runs
+type processSorter interface {
+	SetProcessSort(sortBy string)
+}
+
 // SystemMonitor orchestrates the monitoring application lifecycle
 type SystemMonitor struct {
 	config    *config.Config
 	collector collector.MetricsCollector
 	renderer  render.Renderer
 	logger    logger.Logger
+	history   *history.History // Recent samples, nil if graphs are disabled
+	alerts    *alert.Engine
 	wg        sync.WaitGroup
+
+	// Alert notifiers, each fed by its own queue so that a slow target
+	// delays neither the display nor the other notifiers
+	notifyQueues []chan alert.Event
+	notifyWG     sync.WaitGroup
+	notifyCtx    context.Context
+	notifyCancel context.CancelFunc
+
+	// Interactive mode, nil otherwise
+	input      *tui.Input
+	controller *tui.Controller
 }
 
 // NewSystemMonitor creates a new system monitor instance
@@ -27,59 +53,285 @@ func NewSystemMonitor(
 	renderer render.Renderer,
 	logger logger.Logger,
 ) *SystemMonitor {
-	return &SystemMonitor{
+	m := &SystemMonitor{
 		config:    cfg,
 		collector: collector,
 		renderer:  renderer,
 		logger:    logger,
+		alerts:    alert.NewEngine(&cfg.Thresholds, &cfg.Alerts),
+	}
+	m.notifyCtx, m.notifyCancel = context.WithCancel(context.Background())
+
+	// Keep recent samples for renderers that graph trends
+	if cfg.History > 0 {
+		m.history = history.New(cfg.History)
+		if hr, ok := renderer.(render.HistoryRenderer); ok {
+			hr.SetHistory(m.history)
+		}
 	}
+	return m
 }
 
-// Start begins monitoring and blocks until context is cancelled
-func (m *SystemMonitor) Start(ctx context.Context) error {
-	metricsChan := make(chan *models.Metrics, 1)
+// SetInteractive enables keyboard control of the display. The renderer
+// must support views. Stop restores the terminal.
+func (m *SystemMonitor) SetInteractive(input *tui.Input, controller *tui.Controller) error {
+	if _, ok := m.renderer.(render.ViewRenderer); !ok {
+		return fmt.Errorf("interactive mode is not supported by the %T renderer", m.renderer)
+	}
+	m.input = input
+	m.controller = controller
+	return nil
+}
 
-	// Start collector goroutine
-	m.wg.Add(1)
+// notifyQueueSize is the number of alert events that can wait for a
+// notifier before further events are dropped
+const notifyQueueSize = 64
+
+// notifyDrainTimeout bounds how long Stop waits for notifiers to deliver
+// queued events before cancelling them
+const notifyDrainTimeout = 10 * time.Second
+
+// AddNotifier delivers every subsequent alert transition to n, in order.
+// Delivery errors are written to the logger. Notifiers that implement
+// io.Closer are closed by Stop once their queue is drained. It must be
+// called before Start.
+func (m *SystemMonitor) AddNotifier(n alert.Notifier) {
+	queue := make(chan alert.Event, notifyQueueSize)
+	m.notifyQueues = append(m.notifyQueues, queue)
+
+	m.notifyWG.Add(1)
 	go func() {
-		defer m.wg.Done()
-		m.collector.Start(ctx, m.config.Interval, metricsChan)
+		defer m.notifyWG.Done()
+		for event := range queue {
+			if err := n.Notify(m.notifyCtx, event); err != nil {
+				m.logError(err)
+			}
+		}
+		if closer, ok := n.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				m.logError(err)
+			}
+		}
 	}()
+}
 
-	// Main loop - receive and render metrics
+// Start begins monitoring and blocks until context is cancelled, the user
+// quits interactive mode or a bounded run completes
+func (m *SystemMonitor) Start(ctx context.Context) error {
+	defer m.restoreOnPanic()
+
+	metricsChan, stopCollector := m.startCollector(ctx)
+
+	var keys <-chan tui.Key
+	if m.input != nil {
+		keys = m.input.Keys()
+	}
+	var last *models.Metrics
+
+	// Bounded runs skip the first sample, whose rates are still zero.
+	// Unbounded runs show it, so the display fills in at once.
+	warmup := m.config.Bounded()
+	samples := 0
+
+	var deadline <-chan time.Time
+	if m.config.Duration > 0 {
+		timer := time.NewTimer(m.config.Duration)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	// Main loop - receive and render metrics, and handle key presses
 	for {
 		select {
 		case <-ctx.Done():
 			// Wait for collector to finish
 			m.wg.Wait()
 			return ctx.Err()
+		case <-deadline:
+			stopCollector()
+			return nil
 		case metrics, ok := <-metricsChan:
 			if !ok {
 				// Channel closed, collector stopped
 				return nil
 			}
+			if warmup {
+				warmup = false
+				continue
+			}
+			// Record history even while paused, so trends have no gap
+			// when the display resumes
+			if m.history != nil {
+				m.history.Record(metrics)
+			}
+
+			// Alerts are evaluated before rendering so the frame
+			// shows their current state
+			m.evaluateAlerts(metrics)
 
-			// Render metrics
-			if err := m.renderer.Render(metrics); err != nil {
-				// Log error but continue
-				if m.logger != nil {
-					m.logger.LogError(err)
+			// Render metrics unless the display is paused. While paused,
+			// last keeps the frame on screen, so redraws after key presses
+			// show it rather than newer samples.
+			if m.controller == nil || !m.controller.Paused() {
+				last = metrics
+				if m.controller != nil {
+					m.controller.Observe(metrics)
 				}
+				m.render(metrics)
 			}
 
-			// Log metrics if logger is configured
+			// Log metrics if logger is configured. Errors are already
+			// logged to stderr by the logger.
 			if m.logger != nil {
-				if err := m.logger.LogMetrics(metrics); err != nil {
-					// Errors are already logged to stderr by the logger
-					continue
+				m.logger.LogMetrics(metrics)
+			}
+
+			samples++
+			if m.config.Count > 0 && samples >= m.config.Count {
+				stopCollector()
+				return nil
+			}
+		case key, ok := <-keys:
+			if !ok {
+				// Input closed, keep monitoring without keyboard control
+				keys = nil
+				continue
+			}
+
+			// Scrolling is bounded by the window of the last frame,
+			// which follows the terminal size
+			m.controller.SetScrollRows(m.renderer.(render.ViewRenderer).ScrollRows())
+
+			switch m.controller.HandleKey(key) {
+			case tui.ActionQuit:
+				stopCollector()
+				return nil
+			case tui.ActionRestart:
+				// Restarting collects immediately, so the new settings
+				// take effect without waiting for the old interval
+				stopCollector()
+				m.config.Interval = m.controller.Interval()
+				if sorter, ok := m.collector.(processSorter); ok {
+					sorter.SetProcessSort(m.controller.Sort())
+				}
+				metricsChan, stopCollector = m.startCollector(ctx)
+			case tui.ActionRedraw:
+				if last != nil {
+					m.render(last)
 				}
 			}
 		}
 	}
 }
 
+// startCollector runs the collector at the configured interval until the
+// returned stop function is called or ctx is cancelled
+func (m *SystemMonitor) startCollector(ctx context.Context) (<-chan *models.Metrics, func()) {
+	collectorCtx, cancel := context.WithCancel(ctx)
+	metricsChan := make(chan *models.Metrics, 1)
+
+	// Start collector goroutine
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer m.restoreOnPanic()
+		m.collector.Start(collectorCtx, m.config.Interval, metricsChan)
+	}()
+
+	return metricsChan, func() {
+		cancel()
+		m.wg.Wait()
+	}
+}
+
+// evaluateAlerts checks metrics against the thresholds and reports every
+// alert transition to the logger and the renderer
+func (m *SystemMonitor) evaluateAlerts(metrics *models.Metrics) {
+	alertRenderer, _ := m.renderer.(render.AlertRenderer)
+
+	for _, event := range m.alerts.Evaluate(metrics) {
+		if m.logger != nil {
+			// Errors are already logged to stderr by the logger
+			m.logger.LogAlert(event)
+		}
+		if alertRenderer != nil {
+			if err := alertRenderer.RenderAlert(event); err != nil {
+				m.logError(err)
+			}
+		}
+		for _, queue := range m.notifyQueues {
+			select {
+			case queue <- event:
+			default:
+				m.logError(fmt.Errorf("notification queue full, dropped alert: %s", event))
+			}
+		}
+	}
+}
+
+// logError writes an error to the logger, or to stderr without one
+func (m *SystemMonitor) logError(err error) {
+	if m.logger != nil {
+		m.logger.LogError(err)
+		return
+	}
+	log.Printf("Warning: %v", err)
+}
+
+// stopNotifiers lets the notifiers deliver queued events, cancelling
+// deliveries still running after notifyDrainTimeout
+func (m *SystemMonitor) stopNotifiers() {
+	for _, queue := range m.notifyQueues {
+		close(queue)
+	}
+	m.notifyQueues = nil
+
+	done := make(chan struct{})
+	go func() {
+		m.notifyWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(notifyDrainTimeout):
+		m.notifyCancel()
+		<-done
+	}
+	m.notifyCancel()
+}
+
+// render displays metrics with the current interactive view, if any
+func (m *SystemMonitor) render(metrics *models.Metrics) {
+	if m.controller != nil {
+		m.renderer.(render.ViewRenderer).SetView(m.controller.View())
+	}
+
+	if err := m.renderer.Render(metrics); err != nil {
+		// Log error but continue
+		m.logError(err)
+	}
+}
+
+// restoreOnPanic runs the Stop cleanup if the calling goroutine panics,
+// so the terminal is not left in raw mode on the alternate screen, then
+// resumes panicking to report the crash
+func (m *SystemMonitor) restoreOnPanic() {
+	if r := recover(); r != nil {
+		m.Stop()
+		panic(r)
+	}
+}
+
 // Stop performs cleanup and stops monitoring
 func (m *SystemMonitor) Stop() error {
+	// Restore the terminal before the final output
+	if m.input != nil {
+		if err := m.input.Close(); err != nil {
+			return err
+		}
+	}
+
 	// Clear renderer
 	if err := m.renderer.Clear(); err != nil {
 		// Ignore clear errors
@@ -90,6 +342,10 @@ func (m *SystemMonitor) Stop() error {
 		return err
 	}
 
+	// Deliver pending notifications while the logger can still record
+	// their errors
+	m.stopNotifiers()
+
 	// Close logger if present
 	if m.logger != nil {
 		if err := m.logger.Close(); err != nil {
@@ -0,0 +1,148 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// fakeCollector sends numbered samples, starting at 1 in CPU.Overall, at
+// once and then every interval, like the real collector
+type fakeCollector struct {
+	stopped chan struct{} // Closed when Start returns
+}
+
+func newFakeCollector() *fakeCollector {
+	return &fakeCollector{stopped: make(chan struct{})}
+}
+
+func (c *fakeCollector) Collect(ctx context.Context) (*models.Metrics, error) {
+	return &models.Metrics{Timestamp: time.Now()}, nil
+}
+
+func (c *fakeCollector) Start(ctx context.Context, interval time.Duration, out chan<- *models.Metrics) error {
+	defer close(c.stopped)
+	defer close(out)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for n := 1; ; n++ {
+		metrics := &models.Metrics{Timestamp: time.Now(), CPU: models.CPUStats{Overall: float64(n)}}
+		select {
+		case out <- metrics:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// recorder is a renderer and logger that records the samples it is given
+type recorder struct {
+	mu      sync.Mutex
+	samples []float64
+}
+
+func (r *recorder) record(metrics *models.Metrics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples = append(r.samples, metrics.CPU.Overall)
+	return nil
+}
+
+func (r *recorder) Render(metrics *models.Metrics) error     { return r.record(metrics) }
+func (r *recorder) LogMetrics(metrics *models.Metrics) error { return r.record(metrics) }
+func (r *recorder) LogAlert(alert.Event) error               { return nil }
+func (r *recorder) LogError(error) error                     { return nil }
+func (r *recorder) Clear() error                             { return nil }
+func (r *recorder) Close() error                             { return nil }
+
+// Samples returns the numbers of the recorded samples
+func (r *recorder) Samples() []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]float64(nil), r.samples...)
+}
+
+// runBounded runs a monitor with the given limits until it stops by itself
+func runBounded(t *testing.T, count int, duration time.Duration) (rendered, logged []float64, c *fakeCollector) {
+	t.Helper()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Interval = 10 * time.Millisecond
+	cfg.Count = count
+	cfg.Duration = duration
+
+	c = newFakeCollector()
+	renderer, log := &recorder{}, &recorder{}
+	m := NewSystemMonitor(cfg, c, renderer, log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	return renderer.Samples(), log.Samples(), c
+}
+
+func TestStartCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		want  []float64
+	}{
+		{"once", 1, []float64{2}},
+		{"count", 3, []float64{2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, logged, _ := runBounded(t, tt.count, 0)
+
+			// The warm-up sample 1 is neither rendered nor logged
+			if !equalSamples(rendered, tt.want) {
+				t.Errorf("rendered samples %v, want %v", rendered, tt.want)
+			}
+			if !equalSamples(logged, tt.want) {
+				t.Errorf("logged samples %v, want %v", logged, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartDuration(t *testing.T) {
+	rendered, _, c := runBounded(t, 0, 100*time.Millisecond)
+
+	select {
+	case <-c.stopped:
+	default:
+		t.Fatal("collector still running after Start returned")
+	}
+
+	if len(rendered) == 0 || rendered[0] != 2 {
+		t.Errorf("rendered samples %v, want them to start after the warm-up sample", rendered)
+	}
+}
+
+func equalSamples(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
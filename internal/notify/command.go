@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/logger"
+)
+
+// hookOutputLimit is the amount of a failed command's output included in
+// the logged error
+const hookOutputLimit = 1024
+
+// hookWaitDelay is how long a killed command's output pipes may stay open,
+// e.g. held by a background child, before they are closed
+const hookWaitDelay = time.Second
+
+// cooldownKey identifies the runs of one hook for one alert and state
+type cooldownKey struct {
+	hook  int
+	alert string
+	state alert.State
+}
+
+// CommandRunner implements alert.Notifier by running the configured hook
+// commands. Commands run in the background, at most a fixed number at a
+// time; failures are written to the logger.
+type CommandRunner struct {
+	hooks  []config.Hook
+	host   string
+	logger logger.Logger // nil to discard failures
+
+	slots   chan struct{} // Semaphore limiting concurrent commands
+	running sync.WaitGroup
+
+	mu      sync.Mutex
+	lastRun map[cooldownKey]time.Time
+}
+
+// NewCommandRunner creates a runner for the given hooks, running at most
+// concurrency commands at once. host names the monitored machine.
+func NewCommandRunner(hooks []config.Hook, concurrency int, host string, log logger.Logger) *CommandRunner {
+	return &CommandRunner{
+		hooks:   hooks,
+		host:    host,
+		logger:  log,
+		slots:   make(chan struct{}, concurrency),
+		lastRun: make(map[cooldownKey]time.Time),
+	}
+}
+
+// Notify starts the hooks that match an alert that fired or resolved. It
+// returns once they have started, waiting while the concurrency limit is
+// reached. Hooks still within their cooldown for this alert and state are
+// skipped.
+func (r *CommandRunner) Notify(ctx context.Context, event alert.Event) error {
+	if !ShouldNotify(event) {
+		return nil
+	}
+	payload := NewPayload(r.host, event)
+
+	for i, hook := range r.hooks {
+		if !hookMatches(hook, event) || !r.claimCooldown(i, hook, event) {
+			continue
+		}
+
+		select {
+		case r.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		r.running.Add(1)
+		go func(hook config.Hook) {
+			defer r.running.Done()
+			defer func() { <-r.slots }()
+
+			if err := runHook(ctx, hook, payload); err != nil {
+				r.logError(err)
+			}
+		}(hook)
+	}
+	return nil
+}
+
+// logError writes a hook failure to the logger, or to stderr without one
+func (r *CommandRunner) logError(err error) {
+	if r.logger != nil {
+		r.logger.LogError(err)
+		return
+	}
+	log.Printf("Warning: %v", err)
+}
+
+// Close waits for running commands to finish. Commands are bounded by
+// their timeouts and by the context given to Notify.
+func (r *CommandRunner) Close() error {
+	r.running.Wait()
+	return nil
+}
+
+// claimCooldown reports whether the hook may run for the event, and if so
+// starts its cooldown
+func (r *CommandRunner) claimCooldown(i int, hook config.Hook, event alert.Event) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := cooldownKey{i, event.Name(), event.State}
+	if last, ok := r.lastRun[key]; ok && event.Time.Sub(last) < hook.Cooldown {
+		return false
+	}
+	r.lastRun[key] = event.Time
+	return true
+}
+
+// hookMatches reports whether the hook is configured for the event's
+// metric and state
+func hookMatches(hook config.Hook, event alert.Event) bool {
+	if len(hook.On) > 0 && !contains(hook.On, string(event.State)) {
+		return false
+	}
+	if len(hook.Metrics) > 0 && !contains(hook.Metrics, event.Metric) {
+		return false
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// runHook runs a hook command with the alert in its environment and as
+// JSON on stdin, returning an error describing a failure or non-zero exit
+func runHook(ctx context.Context, hook config.Hook, payload Payload) error {
+	input, err := marshalJSON(payload)
+	if err != nil {
+		return fmt.Errorf("hook %q: %w", hook.Command, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", hook.Command)
+	cmd.Env = append(os.Environ(), hookEnv(payload)...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.WaitDelay = hookWaitDelay
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	desc := fmt.Sprintf("hook %q for %s %s", hook.Command, payload.Metric, payload.State)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s timed out after %v%s", desc, hook.Timeout, formatOutput(output.Bytes()))
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("%s exited with status %d%s", desc, exitErr.ExitCode(), formatOutput(output.Bytes()))
+	}
+	return fmt.Errorf("%s failed: %w", desc, err)
+}
+
+// hookEnv returns the environment variables describing an alert
+func hookEnv(p Payload) []string {
+	return []string{
+		"SYSMON_HOST=" + p.Host,
+		"SYSMON_METRIC=" + p.Metric,
+		"SYSMON_INSTANCE=" + p.Instance,
+		"SYSMON_VALUE=" + strconv.FormatFloat(p.Value, 'f', -1, 64),
+		"SYSMON_THRESHOLD=" + strconv.FormatFloat(p.Threshold, 'f', -1, 64),
+		"SYSMON_STATE=" + p.State,
+		"SYSMON_PREVIOUS_STATE=" + p.Previous,
+		"SYSMON_TIMESTAMP=" + p.Timestamp.Format(time.RFC3339),
+		"SYSMON_SINCE=" + p.Since.Format(time.RFC3339),
+		"SYSMON_SUMMARY=" + p.Summary,
+	}
+}
+
+// formatOutput appends the end of a command's output to an error message
+func formatOutput(output []byte) string {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return ""
+	}
+	if len(output) > hookOutputLimit {
+		output = output[len(output)-hookOutputLimit:]
+	}
+	return ": " + string(output)
+}
@@ -0,0 +1,157 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// errorLog is a logger.Logger recording LogError calls
+type errorLog struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *errorLog) LogMetrics(*models.Metrics) error { return nil }
+func (l *errorLog) LogAlert(alert.Event) error       { return nil }
+func (l *errorLog) Close() error                     { return nil }
+
+func (l *errorLog) LogError(err error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, err.Error())
+	return nil
+}
+
+// testHook returns a hook running command with test-friendly limits
+func testHook(command string) config.Hook {
+	return config.Hook{Command: command, Timeout: 5 * time.Second}
+}
+
+// runHooks notifies a runner of events and waits for the commands
+func runHooks(t *testing.T, hooks []config.Hook, concurrency int, events ...alert.Event) *errorLog {
+	t.Helper()
+
+	log := &errorLog{}
+	r := NewCommandRunner(hooks, concurrency, "web-1", log)
+	for _, event := range events {
+		if err := r.Notify(context.Background(), event); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+	r.Close()
+	return log
+}
+
+func TestCommandRunnerPassesAlert(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "env")
+	stdinFile := filepath.Join(dir, "stdin")
+
+	hook := testHook(`echo "$SYSMON_HOST $SYSMON_METRIC $SYSMON_INSTANCE $SYSMON_VALUE $SYSMON_THRESHOLD $SYSMON_STATE" > ` +
+		envFile + ` && cat > ` + stdinFile)
+	log := runHooks(t, []config.Hook{hook}, 1, firing)
+
+	if len(log.errors) > 0 {
+		t.Fatalf("hook failed: %v", log.errors)
+	}
+	env, _ := os.ReadFile(envFile)
+	if got, want := strings.TrimSpace(string(env)), "web-1 disk / 93.5 90 firing"; got != want {
+		t.Errorf("environment = %q, want %q", got, want)
+	}
+	stdin, _ := os.ReadFile(stdinFile)
+	if !strings.Contains(string(stdin), `"summary":"disk / firing: 93.50 > 90.00"`) {
+		t.Errorf("stdin = %s, want the JSON payload", stdin)
+	}
+}
+
+func TestCommandRunnerLogsFailures(t *testing.T) {
+	timeout := testHook("sleep 5")
+	timeout.Timeout = 50 * time.Millisecond
+
+	log := runHooks(t, []config.Hook{testHook("echo disk full >&2; exit 3"), timeout}, 2, firing)
+
+	if len(log.errors) != 2 {
+		t.Fatalf("logged %d errors %v, want 2", len(log.errors), log.errors)
+	}
+	joined := strings.Join(log.errors, "\n")
+	for _, want := range []string{"exited with status 3: disk full", "timed out after 50ms"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("errors %q do not mention %q", log.errors, want)
+		}
+	}
+}
+
+func TestCommandRunnerLogsToStderrWithoutLogger(t *testing.T) {
+	var stderr bytes.Buffer
+	log.SetOutput(&stderr)
+	defer log.SetOutput(os.Stderr)
+
+	r := NewCommandRunner([]config.Hook{testHook("exit 3")}, 1, "web-1", nil)
+	if err := r.Notify(context.Background(), firing); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	r.Close()
+
+	if !strings.Contains(stderr.String(), "Warning: ") || !strings.Contains(stderr.String(), "exited with status 3") {
+		t.Errorf("stderr = %q, want the hook failure", stderr.String())
+	}
+}
+
+func TestCommandRunnerFilters(t *testing.T) {
+	dir := t.TempDir()
+	record := func(name string) string {
+		return "echo " + name + " >> " + filepath.Join(dir, "runs")
+	}
+
+	onResolve := testHook(record("resolved"))
+	onResolve.On = []string{"resolved"}
+	onCPU := testHook(record("cpu"))
+	onCPU.Metrics = []string{config.MetricCPU}
+	onDisk := testHook(record("disk"))
+	onDisk.Metrics = []string{config.MetricDisk}
+	onDisk.Cooldown = time.Hour
+
+	resolved := firing
+	resolved.State, resolved.Previous = alert.StateResolved, alert.StateFiring
+	resolved.Time = firing.Time.Add(time.Minute)
+	refired := firing
+	refired.Time = firing.Time.Add(2 * time.Minute)
+
+	// The disk hook runs for the first firing and the resolution, but the
+	// second firing is within its cooldown
+	runHooks(t, []config.Hook{onResolve, onCPU, onDisk}, 1, firing, resolved, refired)
+
+	runs, _ := os.ReadFile(filepath.Join(dir, "runs"))
+	if got, want := strings.Fields(string(runs)), []string{"disk", "resolved", "disk"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("runs = %v, want %v", got, want)
+	}
+}
+
+func TestCommandRunnerConcurrencyLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	// Each command fails if another one is running
+	command := `mkdir ` + filepath.Join(dir, "lock") + ` || exit 1; sleep 0.05; rmdir ` + filepath.Join(dir, "lock")
+	var events []alert.Event
+	for _, mount := range []string{"/", "/home", "/var"} {
+		event := firing
+		event.Instance = mount
+		events = append(events, event)
+	}
+
+	log := runHooks(t, []config.Hook{testHook(command)}, 1, events...)
+	if len(log.errors) > 0 {
+		t.Errorf("commands overlapped: %v", log.errors)
+	}
+}
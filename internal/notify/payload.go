@@ -0,0 +1,53 @@
+// Package notify delivers alert transitions to external systems.
+package notify
+
+import (
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+)
+
+// Payload is the alert data sent to notification targets, and the data
+// available to webhook body templates
+type Payload struct {
+	Host      string    `json:"host"`
+	Metric    string    `json:"metric"`
+	Instance  string    `json:"instance,omitempty"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	State     string    `json:"state"`
+	Previous  string    `json:"previous"`
+	Timestamp time.Time `json:"timestamp"`
+	Since     time.Time `json:"since"`
+	Summary   string    `json:"summary"` // One-line description, e.g. "disk / firing: 93.20 > 90.00"
+}
+
+// NewPayload describes an alert event on the given host
+func NewPayload(host string, event alert.Event) Payload {
+	return Payload{
+		Host:      host,
+		Metric:    event.Metric,
+		Instance:  event.Instance,
+		Value:     event.Value,
+		Threshold: event.Threshold,
+		State:     string(event.State),
+		Previous:  string(event.Previous),
+		Timestamp: event.Time,
+		Since:     event.Since,
+		Summary:   event.String(),
+	}
+}
+
+// ShouldNotify reports whether an event is worth notifying about: an
+// alert firing, or a firing alert resolving. Pending alerts, and pending
+// alerts that clear before firing, are not.
+func ShouldNotify(event alert.Event) bool {
+	switch event.State {
+	case alert.StateFiring:
+		return true
+	case alert.StateResolved:
+		return event.Previous == alert.StateFiring
+	default:
+		return false
+	}
+}
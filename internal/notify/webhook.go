@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+)
+
+// Webhook implements alert.Notifier by POSTing alert payloads to a URL
+type Webhook struct {
+	url      string
+	target   string // Host part of the URL, for error messages
+	template *template.Template
+	host     string
+	client   *http.Client
+	retries  int
+	backoff  time.Duration
+}
+
+// templateFuncs are available in webhook body templates
+var templateFuncs = template.FuncMap{
+	// json encodes a value, for embedding strings in JSON bodies
+	"json": func(v interface{}) (string, error) {
+		data, err := marshalJSON(v)
+		return string(data), err
+	},
+}
+
+// marshalJSON encodes v without escaping <, > and &, which chat services
+// would otherwise show as \u003c and the like
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// NewWebhook creates a webhook notifier. host names the monitored machine
+// in payloads.
+func NewWebhook(webhook config.Webhook, opts config.Notify, host string) (*Webhook, error) {
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %w", withoutURL(err))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("webhook url must be http or https, got: %q", u.Scheme)
+	}
+
+	w := &Webhook{
+		url:     webhook.URL,
+		target:  u.Host,
+		host:    host,
+		client:  &http.Client{Timeout: opts.Timeout},
+		retries: opts.Retries,
+		backoff: opts.Backoff,
+	}
+
+	if webhook.Template != "" {
+		w.template, err = template.New("webhook").Funcs(templateFuncs).Parse(webhook.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template for %s: %w", w.target, err)
+		}
+	}
+	return w, nil
+}
+
+// Notify POSTs an alert that fired or resolved, retrying failed
+// deliveries with exponential backoff. Other transitions are ignored.
+func (w *Webhook) Notify(ctx context.Context, event alert.Event) error {
+	if !ShouldNotify(event) {
+		return nil
+	}
+
+	body, err := w.body(NewPayload(w.host, event))
+	if err != nil {
+		return fmt.Errorf("webhook to %s: %w", w.target, err)
+	}
+
+	delay := w.backoff
+	for attempt := 0; ; attempt++ {
+		err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= w.retries || !retryable(err) {
+			return fmt.Errorf("webhook to %s failed after %d attempt(s): %w", w.target, attempt+1, err)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("webhook to %s: %w", w.target, ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// body renders the request body from the template, or as JSON
+func (w *Webhook) body(payload Payload) ([]byte, error) {
+	if w.template == nil {
+		return marshalJSON(payload)
+	}
+
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// post makes a single delivery attempt
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return withoutURL(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sysmon")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return withoutURL(err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{code: resp.StatusCode}
+	}
+	return nil
+}
+
+// withoutURL strips the URL from a *url.Error. Webhook URLs of chat and
+// paging services carry a secret token in their path or query, which must
+// not reach stderr or the log file; errors name the host instead.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// statusError reports a non-2xx response
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s", e.code, http.StatusText(e.code))
+}
+
+// retryable reports whether a failed attempt may succeed when repeated.
+// Client errors other than timeouts and rate limiting will not.
+func retryable(err error) bool {
+	status, ok := err.(*statusError)
+	if !ok {
+		return true
+	}
+	if status.code == http.StatusRequestTimeout || status.code == http.StatusTooManyRequests {
+		return true
+	}
+	return status.code < 400 || status.code > 499
+}
@@ -0,0 +1,200 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+)
+
+var firing = alert.Event{
+	Metric:    "disk",
+	Instance:  "/",
+	State:     alert.StateFiring,
+	Previous:  alert.StatePending,
+	Value:     93.5,
+	Threshold: 90,
+	Time:      time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC),
+	Since:     time.Date(2024, 1, 15, 10, 30, 15, 0, time.UTC),
+}
+
+// recorder is a webhook endpoint that answers with the given status codes
+// in turn, then 200, and records the request bodies
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.bodies = append(rec.bodies, string(body))
+	if len(rec.statuses) > 0 {
+		w.WriteHeader(rec.statuses[0])
+		rec.statuses = rec.statuses[1:]
+	}
+}
+
+// testOptions retries quickly
+var testOptions = config.Notify{Timeout: time.Second, Retries: 2, Backoff: time.Millisecond}
+
+func newTestWebhook(t *testing.T, rec *recorder, tmpl string) *Webhook {
+	t.Helper()
+
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+
+	w, err := NewWebhook(config.Webhook{URL: server.URL, Template: tmpl}, testOptions, "web-1")
+	if err != nil {
+		t.Fatalf("NewWebhook: %v", err)
+	}
+	return w
+}
+
+func TestWebhookPostsJSONPayload(t *testing.T) {
+	rec := &recorder{}
+	w := newTestWebhook(t, rec, "")
+
+	if err := w.Notify(context.Background(), firing); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(rec.bodies) != 1 {
+		t.Fatalf("got %d requests, want 1", len(rec.bodies))
+	}
+
+	var got Payload
+	if err := json.Unmarshal([]byte(rec.bodies[0]), &got); err != nil {
+		t.Fatalf("body is not a JSON payload: %v", err)
+	}
+	want := NewPayload("web-1", firing)
+	if got != want {
+		t.Errorf("payload = %+v, want %+v", got, want)
+	}
+}
+
+func TestWebhookTemplate(t *testing.T) {
+	rec := &recorder{}
+	w := newTestWebhook(t, rec, `{"text": {{json .Summary}}, "host": "{{.Host}}", "value": {{printf "%.1f" .Value}}}`)
+
+	if err := w.Notify(context.Background(), firing); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	want := `{"text": "disk / firing: 93.50 > 90.00", "host": "web-1", "value": 93.5}`
+	if len(rec.bodies) != 1 || rec.bodies[0] != want {
+		t.Errorf("bodies = %q, want [%q]", rec.bodies, want)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		wantErr  bool
+		requests int
+	}{
+		{"succeeds after server errors", []int{500, 503}, false, 3},
+		{"gives up after retries", []int{500, 500, 500}, true, 3},
+		{"retries rate limiting", []int{429}, false, 2},
+		{"does not retry client errors", []int{400}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{statuses: tt.statuses}
+			w := newTestWebhook(t, rec, "")
+
+			err := w.Notify(context.Background(), firing)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(rec.bodies) != tt.requests {
+				t.Errorf("got %d requests, want %d", len(rec.bodies), tt.requests)
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	opts := config.Notify{Timeout: 20 * time.Millisecond}
+	w, err := NewWebhook(config.Webhook{URL: server.URL}, opts, "web-1")
+	if err != nil {
+		t.Fatalf("NewWebhook: %v", err)
+	}
+
+	err = w.Notify(context.Background(), firing)
+	if err == nil || !strings.Contains(err.Error(), "Timeout") {
+		t.Errorf("Notify error = %v, want a timeout", err)
+	}
+}
+
+func TestWebhookErrorsHideURL(t *testing.T) {
+	// Nothing listens on port 1, so the connection is refused
+	const secret = "/services/T000/B000/SECRETTOKEN?key=SECRETKEY"
+	w, err := NewWebhook(config.Webhook{URL: "http://127.0.0.1:1" + secret}, config.Notify{Timeout: time.Second}, "web-1")
+	if err != nil {
+		t.Fatalf("NewWebhook: %v", err)
+	}
+
+	err = w.Notify(context.Background(), firing)
+	if err == nil {
+		t.Fatal("Notify error = nil, want a connection error")
+	}
+	if strings.Contains(err.Error(), "SECRET") || !strings.Contains(err.Error(), "127.0.0.1:1") {
+		t.Errorf("Notify error = %q, want the host without the path or query", err)
+	}
+
+	_, err = NewWebhook(config.Webhook{URL: "http://example.com/SECRETTOKEN/%zz"}, testOptions, "web-1")
+	if err == nil || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("NewWebhook error = %v, want one without the url", err)
+	}
+}
+
+func TestWebhookSkipsUnnotifiedTransitions(t *testing.T) {
+	rec := &recorder{}
+	w := newTestWebhook(t, rec, "")
+
+	pending := firing
+	pending.State, pending.Previous = alert.StatePending, alert.StateInactive
+	cancelled := firing
+	cancelled.State, cancelled.Previous = alert.StateResolved, alert.StatePending
+	resolved := firing
+	resolved.State, resolved.Previous = alert.StateResolved, alert.StateFiring
+
+	for _, event := range []alert.Event{pending, cancelled, resolved} {
+		if err := w.Notify(context.Background(), event); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+	if len(rec.bodies) != 1 || !strings.Contains(rec.bodies[0], `"state":"resolved"`) {
+		t.Errorf("bodies = %q, want only the resolution of a firing alert", rec.bodies)
+	}
+}
+
+func TestNewWebhookRejectsBadConfig(t *testing.T) {
+	for _, hook := range []config.Webhook{
+		{URL: "ftp://example.com/hook"},
+		{URL: "http://example.com/hook", Template: "{{.Missing"},
+	} {
+		if _, err := NewWebhook(hook, testOptions, "web-1"); err == nil {
+			t.Errorf("NewWebhook(%+v) succeeded, want an error", hook)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// gaugeBlocks are partially filled gauge cells in eighths, emptiest first
+var gaugeBlocks = []rune(" ▏▎▍▌▋▊▉")
+
+// gaugeWidth is the number of cells in a bar gauge
+const gaugeWidth = 20
+
+// sparkline draws one bar per value, scaled so that scale is a full bar.
+// A scale of zero scales to the largest value, for unbounded metrics such
+// as rates.
+func sparkline(values []float64, scale float64) string {
+	if scale <= 0 {
+		for _, v := range values {
+			scale = math.Max(scale, v)
+		}
+	}
+
+	var line strings.Builder
+	for _, v := range values {
+		level := 0
+		if scale > 0 {
+			level = int(v/scale*float64(len(sparkBlocks)-1) + 0.5)
+		}
+		level = min(max(level, 0), len(sparkBlocks)-1)
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
+
+// gauge draws a bar of width cells filled to percent, using partial blocks
+// for an eighth-of-a-cell resolution
+func gauge(percent float64, width int) string {
+	percent = math.Min(math.Max(percent, 0), 100)
+	eighths := int(percent/100*float64(width*8) + 0.5)
+
+	var bar strings.Builder
+	bar.WriteString("[")
+	bar.WriteString(strings.Repeat("█", eighths/8))
+	if cells := eighths / 8; cells < width {
+		bar.WriteRune(gaugeBlocks[eighths%8])
+		bar.WriteString(strings.Repeat(" ", width-cells-1))
+	}
+	bar.WriteString("]")
+	return bar.String()
+}
+
+// formatGauge draws a bar gauge of a percentage, colored against its
+// threshold
+func (r *TerminalRenderer) formatGauge(percent, threshold float64) string {
+	return " " + r.colorizeValue(gauge(percent, gaugeWidth), percent, threshold)
+}
+
+// formatTrend draws a sparkline of recent samples, right-aligned in the
+// history window so that lines stay aligned while the window fills
+func (r *TerminalRenderer) formatTrend(values []float64, scale float64) string {
+	pad := r.history.Size() - len(values)
+	if pad < 0 {
+		pad = 0
+	}
+	return " " + strings.Repeat(" ", pad) + sparkline(values, scale)
+}
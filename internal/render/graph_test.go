@@ -0,0 +1,45 @@
+package render
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		scale  float64
+		want   string
+	}{
+		{"empty", nil, 100, ""},
+		{"fixed scale", []float64{0, 50, 100}, 100, "▁▅█"},
+		{"clamped", []float64{-10, 150}, 100, "▁█"},
+		{"auto scale", []float64{1, 2, 4}, 0, "▃▅█"},
+		{"all zero", []float64{0, 0}, 0, "▁▁"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.scale); got != tt.want {
+				t.Errorf("sparkline(%v, %v) = %q, want %q", tt.values, tt.scale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGauge(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "[    ]"},
+		{50, "[██  ]"},
+		{56.25, "[██▎ ]"},
+		{100, "[████]"},
+		{120, "[████]"},
+	}
+
+	for _, tt := range tests {
+		if got := gauge(tt.percent, 4); got != tt.want {
+			t.Errorf("gauge(%v, 4) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package render
 
-import "github.com/sysmon/system-monitor-cli/internal/models"
+import (
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/history"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
 
 // Renderer defines the interface for rendering metrics
 type Renderer interface {
@@ -13,3 +17,33 @@ type Renderer interface {
 	// Close performs cleanup and releases resources
 	Close() error
 }
+
+// ViewRenderer is a Renderer whose display state can be changed
+// interactively
+type ViewRenderer interface {
+	Renderer
+
+	// SetView sets the display state used by subsequent renders
+	SetView(view View)
+
+	// ScrollRows returns the number of list entries shown at once in the
+	// last frame, which depends on the terminal size
+	ScrollRows() int
+}
+
+// HistoryRenderer is a Renderer that can graph recent samples
+type HistoryRenderer interface {
+	Renderer
+
+	// SetHistory sets the samples graphed by subsequent renders
+	SetHistory(h *history.History)
+}
+
+// AlertRenderer is a Renderer that displays alert state transitions
+type AlertRenderer interface {
+	Renderer
+
+	// RenderAlert records an alert transition. Renderers may display it
+	// straight away or with the next metrics.
+	RenderAlert(event alert.Event) error
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 
+	"github.com/sysmon/system-monitor-cli/internal/alert"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 )
 
@@ -27,6 +28,14 @@ func (r *JSONRenderer) Render(metrics *models.Metrics) error {
 	return r.encoder.Encode(metrics)
 }
 
+// RenderAlert writes an alert transition as a line of its own, wrapped in
+// an "alert" object to tell it apart from metrics lines
+func (r *JSONRenderer) RenderAlert(event alert.Event) error {
+	return r.encoder.Encode(struct {
+		Alert alert.Event `json:"alert"`
+	}{event})
+}
+
 // Clear is a no-op for JSON renderer
 func (r *JSONRenderer) Clear() error {
 	return nil
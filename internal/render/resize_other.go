@@ -0,0 +1,9 @@
+//go:build !unix
+
+package render
+
+// watchResize is a no-op on platforms without SIGWINCH; the new terminal
+// size is picked up by the next frame instead
+func watchResize(onResize func()) (stop func()) {
+	return func() {}
+}
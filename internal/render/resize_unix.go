@@ -0,0 +1,33 @@
+//go:build unix
+
+package render
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize calls onResize whenever the terminal window changes size,
+// until the returned stop function is called
+func watchResize(onResize func()) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				onResize()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,207 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI control codes for full-screen output
+const (
+	ansiAltScreenOn  = "\033[?1049h"
+	ansiAltScreenOff = "\033[?1049l"
+	ansiHideCursor   = "\033[?25l"
+	ansiShowCursor   = "\033[?25h"
+	ansiClearBelow   = "\033[J"
+	ansiReset        = "\033[0m"
+)
+
+// screen draws frames on the terminal's alternate screen buffer, rewriting
+// only the lines that changed since the previous frame. This avoids the
+// flicker of clearing the whole screen and keeps the scrollback clean.
+type screen struct {
+	writer io.Writer
+	fd     int // Terminal file descriptor used to query the size
+
+	width  int // Terminal size, zero if unknown
+	height int
+
+	active bool     // The alternate screen is in use
+	prev   []string // Lines of the previous frame, nil to redraw everything
+
+	// Log output is held back while the alternate screen is in use, as
+	// it would overwrite rows the differential redraw assumes unchanged
+	logs      *logBuffer
+	logOutput io.Writer // Log destination to restore on close
+}
+
+// maxHeldLogLines is the number of log messages kept while the alternate
+// screen is in use; older ones are counted and dropped
+const maxHeldLogLines = 20
+
+// logBuffer holds the most recent log messages. The log package writes
+// each message with a single Write call.
+type logBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	dropped int
+}
+
+// Write keeps a log message, dropping the oldest beyond maxHeldLogLines
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines = append(b.lines, string(p))
+	if len(b.lines) > maxHeldLogLines {
+		b.lines = b.lines[1:]
+		b.dropped++
+	}
+	return len(p), nil
+}
+
+// flush writes the held messages to w, after a count of dropped ones
+func (b *logBuffer) flush(w io.Writer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.dropped > 0 {
+		fmt.Fprintf(w, "(%d earlier log messages dropped)\n", b.dropped)
+	}
+	for _, line := range b.lines {
+		io.WriteString(w, line)
+	}
+}
+
+// newScreen creates a screen writing to the terminal behind fd
+func newScreen(writer io.Writer, fd int) *screen {
+	s := &screen{writer: writer, fd: fd}
+	s.updateSize()
+	return s
+}
+
+// updateSize reads the terminal size. A changed size forces the next
+// frame to be drawn in full, since the previous layout no longer applies.
+func (s *screen) updateSize() {
+	width, height, err := term.GetSize(s.fd)
+	if err != nil || (width == s.width && height == s.height) {
+		return
+	}
+	s.width, s.height = width, height
+	s.prev = nil
+}
+
+// draw displays a frame, clipped to the terminal size
+func (s *screen) draw(lines []string) error {
+	var output strings.Builder
+	s.updateSize()
+
+	if !s.active {
+		output.WriteString(ansiAltScreenOn + ansiHideCursor)
+		s.active = true
+
+		s.logs = &logBuffer{}
+		s.logOutput = log.Writer()
+		log.SetOutput(s.logs)
+	}
+	if s.prev == nil {
+		output.WriteString(ansiHome + ansiClearScreen)
+	}
+
+	lines = s.fit(lines)
+	for i, line := range lines {
+		if i < len(s.prev) && s.prev[i] == line {
+			continue
+		}
+		// Move to the start of the row, then replace its contents
+		fmt.Fprintf(&output, "\033[%d;1H%s%s", i+1, ansiClearLine, line)
+	}
+
+	// Blank whatever the previous, longer frame left below this one
+	if len(s.prev) > len(lines) {
+		fmt.Fprintf(&output, "\033[%d;1H%s", len(lines)+1, ansiClearBelow)
+	}
+
+	s.prev = lines
+	_, err := io.WriteString(s.writer, output.String())
+	return err
+}
+
+// clear blanks the screen and forgets the previous frame
+func (s *screen) clear() error {
+	s.prev = nil
+	if !s.active {
+		return nil
+	}
+	_, err := io.WriteString(s.writer, ansiHome+ansiClearScreen)
+	return err
+}
+
+// close shows the cursor and returns to the normal screen buffer, then
+// writes the log messages held back meanwhile
+func (s *screen) close() error {
+	if !s.active {
+		return nil
+	}
+	s.active = false
+	s.prev = nil
+	_, err := io.WriteString(s.writer, ansiShowCursor+ansiAltScreenOff)
+
+	log.SetOutput(s.logOutput)
+	s.logs.flush(s.logOutput)
+	s.logs = nil
+	return err
+}
+
+// fit clips a frame to the terminal size. Lines are cut at the terminal
+// width so they don't wrap and shift the rows below, and lines beyond the
+// terminal height are replaced by a count.
+func (s *screen) fit(lines []string) []string {
+	if s.height > 0 && len(lines) > s.height {
+		hidden := len(lines) - s.height + 1
+		lines = append(lines[:s.height-1:s.height-1],
+			fmt.Sprintf("-- %d more lines, enlarge the terminal to see them --", hidden))
+	}
+
+	if s.width <= 0 {
+		return lines
+	}
+	fitted := make([]string, len(lines))
+	for i, line := range lines {
+		fitted[i] = clipLine(line, s.width)
+	}
+	return fitted
+}
+
+// clipLine cuts a line to width visible characters, ignoring ANSI escape
+// sequences, and resets colors if the cut falls inside colored text
+func clipLine(line string, width int) string {
+	visible := 0
+	for i := 0; i < len(line); {
+		if line[i] == '\033' {
+			// Skip to the final byte of the sequence
+			j := i + 1
+			if j < len(line) && line[j] == '[' {
+				j++
+				for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+					j++
+				}
+			}
+			i = j + 1
+			continue
+		}
+
+		if visible == width {
+			return line[:i] + ansiReset
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		visible++
+	}
+	return line
+}
@@ -0,0 +1,175 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+func TestClipLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  string
+	}{
+		{"short", "CPU Usage:", 20, "CPU Usage:"},
+		{"exact", "abcde", 5, "abcde"},
+		{"cut", "abcdefgh", 5, "abcde" + ansiReset},
+		{"escape sequences are free", "\033[31mred\033[0m", 3, "\033[31mred\033[0m"},
+		{"cut inside color", "\033[31mred text\033[0m", 3, "\033[31mred" + ansiReset},
+		{"multi-byte runes", "↑↓↑↓", 2, "↑↓" + ansiReset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clipLine(tt.line, tt.width); got != tt.want {
+				t.Errorf("clipLine(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestScreen creates a screen of a fixed size writing to a buffer
+func newTestScreen(width, height int) (*screen, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return &screen{writer: &buf, fd: -1, width: width, height: height}, &buf
+}
+
+func TestScreenDrawsOnlyChangedLines(t *testing.T) {
+	s, buf := newTestScreen(80, 24)
+
+	if err := s.draw([]string{"header", "cpu 10%", "mem 20%"}); err != nil {
+		t.Fatalf("draw() error = %v", err)
+	}
+	first := buf.String()
+	if !strings.HasPrefix(first, ansiAltScreenOn+ansiHideCursor) || !strings.Contains(first, ansiClearScreen) {
+		t.Errorf("first frame = %q, want alternate screen, hidden cursor and clear", first)
+	}
+
+	buf.Reset()
+	if err := s.draw([]string{"header", "cpu 15%", "mem 20%"}); err != nil {
+		t.Fatalf("draw() error = %v", err)
+	}
+	second := buf.String()
+	if want := "\033[2;1H" + ansiClearLine + "cpu 15%"; second != want {
+		t.Errorf("second frame = %q, want only row 2 rewritten: %q", second, want)
+	}
+
+	// A shorter frame blanks the rows below it
+	buf.Reset()
+	if err := s.draw([]string{"header"}); err != nil {
+		t.Fatalf("draw() error = %v", err)
+	}
+	if want := "\033[2;1H" + ansiClearBelow; buf.String() != want {
+		t.Errorf("shorter frame = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestScreenFitsHeight(t *testing.T) {
+	s, _ := newTestScreen(80, 3)
+
+	lines := []string{"one", "two", "three", "four", "five"}
+	got := s.fit(lines)
+	if len(got) != 3 || got[1] != "two" || !strings.Contains(got[2], "3 more lines") {
+		t.Errorf("fit() = %q, want two lines and a count of 3 more", got)
+	}
+	if lines[2] != "three" {
+		t.Errorf("fit() modified its input: %q", lines)
+	}
+}
+
+func TestScreenClose(t *testing.T) {
+	s, buf := newTestScreen(80, 24)
+
+	// Nothing to restore before the first frame
+	if err := s.close(); err != nil || buf.Len() != 0 {
+		t.Errorf("close() before draw wrote %q, err = %v", buf.String(), err)
+	}
+
+	s.draw([]string{"header"})
+	buf.Reset()
+	if err := s.close(); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+	if buf.String() != ansiShowCursor+ansiAltScreenOff {
+		t.Errorf("close() wrote %q, want cursor shown and normal screen", buf.String())
+	}
+
+	// Closing again is a no-op
+	buf.Reset()
+	s.close()
+	if buf.Len() != 0 {
+		t.Errorf("second close() wrote %q", buf.String())
+	}
+}
+
+func TestScreenHoldsLogOutput(t *testing.T) {
+	var stderr bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&stderr)
+	defer log.SetFlags(log.Flags())
+	log.SetFlags(0)
+
+	s, buf := newTestScreen(80, 24)
+	s.draw([]string{"header"})
+
+	// Messages logged over the display are held back
+	for i := 1; i <= maxHeldLogLines+2; i++ {
+		log.Printf("warning %d", i)
+	}
+	if stderr.Len() != 0 || strings.Contains(buf.String(), "warning") {
+		t.Fatalf("log output reached the terminal while the screen was active: %q %q", stderr.String(), buf.String())
+	}
+
+	// and written once the normal screen is back, keeping the latest
+	s.close()
+	want := "(2 earlier log messages dropped)\n"
+	for i := 3; i <= maxHeldLogLines+2; i++ {
+		want += fmt.Sprintf("warning %d\n", i)
+	}
+	if stderr.String() != want {
+		t.Errorf("log output after close = %q, want %q", stderr.String(), want)
+	}
+
+	log.Print("after")
+	if !strings.HasSuffix(stderr.String(), "after\n") {
+		t.Error("log output not restored after close")
+	}
+}
+
+func TestScrollRowsFitHeight(t *testing.T) {
+	metrics := &models.Metrics{}
+	for i := 0; i < 20; i++ {
+		metrics.Disk = append(metrics.Disk, models.DiskStats{Mountpoint: fmt.Sprintf("/mnt/%d", i)})
+		metrics.Network = append(metrics.Network, models.NetworkStats{Interface: fmt.Sprintf("eth%d", i)})
+	}
+
+	rows := make(map[int]int)
+	for _, height := range []int{40, 80, 200} {
+		r := NewTerminalRenderer(&bytes.Buffer{}, &config.NewDefaultConfig().Thresholds)
+		s, buf := newTestScreen(120, height)
+		r.screen = s
+		r.SetView(View{Interactive: true})
+		if err := r.Render(metrics); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		rows[height] = r.ScrollRows()
+
+		if strings.Contains(buf.String(), "more lines") {
+			t.Errorf("height %d: frame with %d rows was cut off", height, rows[height])
+		}
+	}
+
+	if rows[40] < 1 || rows[40] >= rows[80] || rows[80] >= rows[200] {
+		t.Errorf("scroll rows by height = %v, want growing with the terminal", rows)
+	}
+	if rows[200] > len(metrics.Disk) {
+		t.Errorf("scroll rows = %d, more than the %d disks", rows[200], len(metrics.Disk))
+	}
+}
@@ -3,11 +3,18 @@ package render
 import (
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/sysmon/system-monitor-cli/internal/alert"
 	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/history"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 	"golang.org/x/term"
 )
@@ -24,6 +31,23 @@ type TerminalRenderer struct {
 	writer     io.Writer
 	thresholds *config.Thresholds
 	useANSI    bool
+
+	// Full-screen state, used when writing ANSI to a terminal. mu guards
+	// rendering, which also happens from the resize handler.
+	mu         sync.Mutex
+	screen     *screen
+	stopResize func()
+	last       *models.Metrics // Most recent metrics, redrawn on resize
+
+	view       View             // Interactive display state
+	scrollRows int              // Disks or interfaces shown at once in interactive mode
+	history    *history.History // Recent samples to graph, nil to disable graphs
+
+	lastOOMTime  time.Time // When OOM kills were last seen, zero if never
+	lastOOMKills uint64    // Number of OOM kills in that sample
+
+	alerts      map[string]alert.Event // Pending and firing alerts by name
+	alertEvents []alert.Event          // Transitions not yet printed, for plain output
 }
 
 // NewTerminalRenderer creates a new terminal renderer
@@ -31,68 +55,280 @@ func NewTerminalRenderer(writer io.Writer, thresholds *config.Thresholds) *Termi
 	// Detect if output supports ANSI codes
 	useANSI := isTerminal(writer)
 
-	return &TerminalRenderer{
+	r := &TerminalRenderer{
 		writer:     writer,
 		thresholds: thresholds,
 		useANSI:    useANSI,
+		scrollRows: DefaultScrollRows,
+	}
+
+	if useANSI {
+		r.screen = newScreen(writer, int(writer.(*os.File).Fd()))
+		r.stopResize = watchResize(r.redraw)
 	}
+	return r
+}
+
+// SetView sets the interactive display state used by subsequent renders
+func (r *TerminalRenderer) SetView(view View) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.view = view
+}
+
+// ScrollRows returns the number of disks or interfaces shown at once in
+// the last frame, which bounds scrolling
+func (r *TerminalRenderer) ScrollRows() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.scrollRows
+}
+
+// SetInline writes frames one after another to the normal screen instead
+// of redrawing them in place on the alternate screen, so they remain in
+// the scrollback after exit. Colours are kept. It must be called before
+// the first Render.
+func (r *TerminalRenderer) SetInline(inline bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if inline && r.screen != nil {
+		r.stopResize()
+		r.stopResize = nil
+		r.screen = nil
+	} else if !inline && r.screen == nil && r.useANSI {
+		r.screen = newScreen(r.writer, int(r.writer.(*os.File).Fd()))
+		r.stopResize = watchResize(r.redraw)
+	}
+}
+
+// SetHistory enables sparklines and bar gauges drawn from the recent
+// samples in h. A nil history disables them.
+func (r *TerminalRenderer) SetHistory(h *history.History) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history = h
+}
+
+// RenderAlert records an alert transition, shown with the next metrics
+func (r *TerminalRenderer) RenderAlert(event alert.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.alerts == nil {
+		r.alerts = make(map[string]alert.Event)
+	}
+	if event.State == alert.StateResolved {
+		delete(r.alerts, event.Name())
+	} else {
+		r.alerts[event.Name()] = event
+	}
+
+	if !r.useANSI {
+		r.alertEvents = append(r.alertEvents, event)
+	}
+	return nil
 }
 
 // Render formats and displays metrics in a terminal-friendly layout
 func (r *TerminalRenderer) Render(metrics *models.Metrics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.last = metrics
+	return r.render(metrics)
+}
+
+// redraw renders the most recent metrics again after a terminal resize
+func (r *TerminalRenderer) redraw() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.last != nil {
+		r.render(r.last)
+	}
+}
+
+// render formats metrics and writes them as a new frame
+func (r *TerminalRenderer) render(metrics *models.Metrics) error {
 	var output strings.Builder
 
-	// Clear screen and move cursor to home if ANSI supported
-	if r.useANSI {
-		output.WriteString(ansiClearScreen)
-		output.WriteString(ansiHome)
-	} else {
+	// Full-screen frames replace each other in place; other output
+	// separates them with a rule
+	if r.screen == nil {
 		output.WriteString("\n" + strings.Repeat("=", 80) + "\n")
 	}
 
 	// Header
 	output.WriteString(r.formatHeader(metrics))
+	output.WriteString(r.formatOOMEvent(metrics))
+	output.WriteString(r.formatAlerts(metrics))
+	if r.view.Interactive {
+		output.WriteString(r.formatStatus())
+	}
 	output.WriteString("\n")
 
+	if r.view.ShowHelp {
+		output.WriteString(r.formatHelp())
+	} else {
+		r.layoutScroll(metrics, strings.Count(output.String(), "\n"))
+		output.WriteString(r.formatSections(metrics))
+	}
+
+	text := output.String()
+	if r.screen != nil {
+		return r.screen.draw(strings.Split(strings.TrimRight(text, "\n"), "\n"))
+	}
+
+	// The terminal is in raw mode while interactive, so line feeds
+	// no longer return the cursor to the first column
+	if r.view.Interactive {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+
+	_, err := r.writer.Write([]byte(text))
+	return err
+}
+
+// layoutScroll sizes the interactive disk and interface windows to the
+// terminal height, given the number of lines above the sections. It picks
+// the largest window whose frame still fits, since a full-screen frame
+// taller than the terminal is cut off.
+func (r *TerminalRenderer) layoutScroll(metrics *models.Metrics, headerLines int) {
+	r.scrollRows = DefaultScrollRows
+	if !r.view.Interactive || r.screen == nil {
+		return
+	}
+	r.screen.updateSize()
+	if r.screen.height <= 0 {
+		return
+	}
+
+	fits := func(rows int) bool {
+		r.scrollRows = rows
+		return headerLines+strings.Count(r.formatSections(metrics), "\n") <= r.screen.height
+	}
+
+	// Frames grow with the window, so search for the largest that fits
+	longest := max(len(metrics.Disk), len(metrics.Network))
+	low, high := 1, max(longest, 1)
+	for low < high {
+		mid := (low + high + 1) / 2
+		if fits(mid) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	r.scrollRows = low
+}
+
+// formatSections formats every metrics section that has data and is not
+// hidden by the view
+func (r *TerminalRenderer) formatSections(metrics *models.Metrics) string {
+	var sections strings.Builder
+
 	// CPU Section
-	output.WriteString(r.formatCPU(metrics.CPU))
-	output.WriteString("\n")
+	if r.view.visible(SectionCPU) {
+		sections.WriteString(r.formatCPU(metrics.CPU))
+		sections.WriteString("\n")
+	}
 
 	// Memory Section
-	output.WriteString(r.formatMemory(metrics.Memory))
-	output.WriteString("\n")
+	if r.view.visible(SectionMemory) {
+		sections.WriteString(r.formatMemory(metrics.Memory))
+		sections.WriteString("\n")
+	}
+
+	// Pressure Section
+	sections.WriteString(r.formatPressure(metrics.Pressure))
+	sections.WriteString("\n")
+
+	// Activity Section
+	if metrics.Activity.ContextSwitches > 0 {
+		sections.WriteString(r.formatActivity(metrics.Activity))
+		sections.WriteString("\n")
+	}
+
+	// Kernel Tables Section
+	if metrics.Kernel.FilesMax > 0 {
+		sections.WriteString(r.formatKernelTables(metrics.Kernel))
+		sections.WriteString("\n")
+	}
+
+	// Cgroup Section
+	if metrics.Cgroup.Available {
+		sections.WriteString(r.formatCgroup(metrics.Cgroup))
+		sections.WriteString("\n")
+	}
+
+	// Sensors Section
+	if len(metrics.Sensors.Temperatures) > 0 || len(metrics.Sensors.Fans) > 0 {
+		sections.WriteString(r.formatSensors(metrics.Sensors))
+		sections.WriteString("\n")
+	}
 
 	// Disk Section
-	if len(metrics.Disk) > 0 {
-		output.WriteString(r.formatDisk(metrics.Disk))
-		output.WriteString("\n")
+	if len(metrics.Disk) > 0 && r.view.visible(SectionDisk) {
+		sections.WriteString(r.formatDisk(metrics.Disk))
+		sections.WriteString("\n")
+	}
+
+	// Disk I/O Section
+	if len(metrics.DiskIO) > 0 && r.view.visible(SectionDisk) {
+		sections.WriteString(r.formatDiskIO(metrics.DiskIO))
+		sections.WriteString("\n")
 	}
 
 	// Network Section
-	if len(metrics.Network) > 0 {
-		output.WriteString(r.formatNetwork(metrics.Network))
-		output.WriteString("\n")
+	if len(metrics.Network) > 0 && r.view.visible(SectionNetwork) {
+		sections.WriteString(r.formatNetwork(metrics.Network))
+		sections.WriteString("\n")
 	}
 
-	_, err := r.writer.Write([]byte(output.String()))
-	return err
+	// Socket Section
+	if (metrics.Sockets.TCPTotal > 0 || metrics.Sockets.UDPTotal > 0) && r.view.visible(SectionNetwork) {
+		sections.WriteString(r.formatSockets(metrics.Sockets))
+		sections.WriteString("\n")
+	}
+
+	// Container Section
+	if len(metrics.Containers) > 0 {
+		sections.WriteString(r.formatContainers(metrics.Containers))
+		sections.WriteString("\n")
+	}
+
+	// Process Section
+	if len(metrics.Processes) > 0 && r.view.visible(SectionProcesses) {
+		sections.WriteString(r.formatProcesses(metrics.Processes))
+		sections.WriteString("\n")
+	}
+
+	return sections.String()
 }
 
 // Clear clears the terminal display
 func (r *TerminalRenderer) Clear() error {
-	if r.useANSI {
-		_, err := r.writer.Write([]byte(ansiClearScreen + ansiHome))
-		return err
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.screen != nil {
+		return r.screen.clear()
 	}
 	return nil
 }
 
-// Close performs cleanup
+// Close restores the terminal. It is safe to call more than once, e.g.
+// from a panic handler.
 func (r *TerminalRenderer) Close() error {
-	// Restore terminal
-	if r.useANSI {
-		_, err := r.writer.Write([]byte("\n"))
-		return err
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Restore the normal screen and cursor
+	if r.screen != nil {
+		r.stopResize()
+		r.stopResize = func() {}
+		return r.screen.close()
 	}
 	return nil
 }
@@ -102,11 +338,158 @@ func (r *TerminalRenderer) formatHeader(metrics *models.Metrics) string {
 	title := "System Monitor"
 	timestamp := metrics.Timestamp.Format("2006-01-02 15:04:05")
 
+	host := metrics.Host
+	summary := fmt.Sprintf("Load: %.2f %.2f %.2f  Uptime: %s  Tasks: %d running, %d total\n",
+		host.Load1, host.Load5, host.Load15, formatUptime(host.Uptime),
+		host.RunningTasks, host.TotalTasks)
+
 	if r.useANSI {
 		titleColor := color.New(color.FgCyan, color.Bold)
-		return fmt.Sprintf("%s - %s\n", titleColor.Sprint(title), timestamp)
+		return fmt.Sprintf("%s - %s\n", titleColor.Sprint(title), timestamp) + summary
+	}
+	return fmt.Sprintf("%s - %s\n", title, timestamp) + summary
+}
+
+// formatStatus creates the interactive status line below the header
+func (r *TerminalRenderer) formatStatus() string {
+	status := fmt.Sprintf("Interval: %s  Sort: %s", r.view.Interval, r.view.Sort)
+
+	var hidden []string
+	for _, s := range []Section{SectionCPU, SectionMemory, SectionDisk, SectionNetwork, SectionProcesses} {
+		if !r.view.visible(s) {
+			hidden = append(hidden, s.String())
+		}
+	}
+	if len(hidden) > 0 {
+		status += "  Hidden: " + strings.Join(hidden, ", ")
 	}
-	return fmt.Sprintf("%s - %s\n", title, timestamp)
+	status += "  Press h for help"
+
+	if r.view.Paused {
+		paused := "  [PAUSED]"
+		if r.useANSI {
+			paused = color.New(color.FgMagenta, color.Bold).Sprint(paused)
+		}
+		status += paused
+	}
+	return status + "\n"
+}
+
+// formatScroll describes the visible part of a scrolled list for its
+// section header, marking the list the arrow keys currently scroll
+func (r *TerminalRenderer) formatScroll(start, end, n int, section Section) string {
+	if end-start == n {
+		return ""
+	}
+	scroll := fmt.Sprintf(" [%d-%d of %d]", start+1, end, n)
+	if r.view.Focus == section {
+		scroll += " ↑↓"
+	}
+	return scroll
+}
+
+// keyBindings lists the interactive keys shown by the help overlay
+var keyBindings = [][2]string{
+	{"q, Ctrl+C", "Quit"},
+	{"space", "Pause or resume updates"},
+	{"+ / -", "Increase or decrease the refresh interval"},
+	{"c m d n p", "Toggle the CPU, memory, disk, network and process sections"},
+	{"tab", "Switch scrolling between disks and interfaces"},
+	{"↑ ↓ / k j", "Scroll the disk or interface list"},
+	{"PgUp PgDn", "Scroll the disk or interface list a page at a time"},
+	{"s", "Sort tables by CPU or memory"},
+	{"h, ?", "Show or hide this help"},
+}
+
+// formatHelp formats the key binding overlay
+func (r *TerminalRenderer) formatHelp() string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Keys:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Keys:\n")
+	}
+
+	for _, binding := range keyBindings {
+		output.WriteString(fmt.Sprintf("  %-12s %s\n", binding[0], binding[1]))
+	}
+	output.WriteString("\n  Press h, ? or Esc to return\n")
+
+	return output.String()
+}
+
+// formatOOMEvent reports OOM kills that happened between samples. On an
+// ANSI terminal the event stays on screen until the next one, since the
+// display is redrawn every interval; plain output prints it once.
+func (r *TerminalRenderer) formatOOMEvent(metrics *models.Metrics) string {
+	if kills := metrics.Activity.NewOOMKills; kills > 0 {
+		r.lastOOMTime = metrics.Timestamp
+		r.lastOOMKills = kills
+	} else if !r.useANSI || r.lastOOMTime.IsZero() {
+		return ""
+	}
+
+	event := fmt.Sprintf("OOM killer invoked: %d process(es) killed at %s",
+		r.lastOOMKills, r.lastOOMTime.Format("15:04:05"))
+	if r.useANSI {
+		return color.New(color.FgRed, color.Bold).Sprint("⚠ "+event) + "\n"
+	}
+	return "[EVENT] " + event + "\n"
+}
+
+// formatAlerts lists the pending and firing alerts below the header. On
+// an ANSI terminal the list is redrawn each frame with the values in
+// metrics; plain output prints each transition once instead.
+func (r *TerminalRenderer) formatAlerts(metrics *models.Metrics) string {
+	var output strings.Builder
+
+	if !r.useANSI {
+		for _, event := range r.alertEvents {
+			output.WriteString("[ALERT] " + event.String() + "\n")
+		}
+		r.alertEvents = nil
+		return output.String()
+	}
+
+	names := make([]string, 0, len(r.alerts))
+	for name := range r.alerts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	current := make(map[string]alert.Reading)
+	for _, reading := range alert.Readings(r.thresholds, metrics) {
+		current[alert.Event{Metric: reading.Metric, Instance: reading.Instance}.Name()] = reading
+	}
+
+	for _, name := range names {
+		event := r.alerts[name]
+
+		// Show the value in this frame. A firing alert may have fallen
+		// below its threshold but not yet to its clear level, and an
+		// instance that disappeared only has the value that triggered it.
+		value := fmt.Sprintf("triggered at %.2f > %.2f", event.Value, event.Threshold)
+		if reading, ok := current[name]; ok {
+			op := ">"
+			if reading.Value <= reading.Threshold {
+				op = "≤"
+			}
+			value = fmt.Sprintf("%.2f %s %.2f", reading.Value, op, reading.Threshold)
+		}
+		line := fmt.Sprintf("⚠ %-8s %-32s %s since %s",
+			strings.ToUpper(string(event.State)), truncate(name, 32),
+			value, event.Since.Format("15:04:05"))
+		if event.State == alert.StateFiring {
+			line = color.New(color.FgRed, color.Bold).Sprint(line)
+		} else {
+			line = color.YellowString(line)
+		}
+		output.WriteString(line + "\n")
+	}
+	return output.String()
 }
 
 // formatCPU formats CPU statistics
@@ -127,7 +510,21 @@ func (r *TerminalRenderer) formatCPU(cpu models.CPUStats) string {
 	if warning {
 		overallStr += " " + r.formatWarning()
 	}
-	output.WriteString(r.colorizeValue(overallStr, cpu.Overall, r.thresholds.CPU) + "\n")
+	overallStr = r.colorizeValue(overallStr, cpu.Overall, r.thresholds.CPU)
+	if r.history != nil {
+		overallStr += r.formatGauge(cpu.Overall, r.thresholds.CPU) + r.formatTrend(r.history.CPU(), 100)
+	}
+	output.WriteString(overallStr + "\n")
+
+	// Per-mode breakdown
+	b := cpu.Breakdown
+	output.WriteString(fmt.Sprintf("  Modes:   usr %.1f%%  nice %.1f%%  sys %.1f%%  irq %.1f%%  soft %.1f%%  guest %.1f%%\n",
+		b.User, b.Nice, b.System, b.IRQ, b.SoftIRQ, b.Guest+b.GuestNice))
+	output.WriteString(r.colorizeValue(fmt.Sprintf("  IOWait:  %6.2f%%", b.IOWait), b.IOWait, r.thresholds.IOWait) +
+		r.modeWarning(b.IOWait, r.thresholds.IOWait) + "\n")
+	output.WriteString(r.colorizeValue(fmt.Sprintf("  Steal:   %6.2f%%", b.Steal), b.Steal, r.thresholds.Steal) +
+		r.modeWarning(b.Steal, r.thresholds.Steal) + "\n")
+	output.WriteString("  " + r.formatCPUBar(b, 50) + "\n")
 
 	// Per-core CPU
 	if len(cpu.PerCore) > 0 {
@@ -138,13 +535,97 @@ func (r *TerminalRenderer) formatCPU(cpu models.CPUStats) string {
 			if warning {
 				coreStr += " " + r.formatWarning()
 			}
-			output.WriteString(r.colorizeValue(coreStr, percent, r.thresholds.CPU) + "\n")
+			line := r.colorizeValue(coreStr, percent, r.thresholds.CPU)
+			if r.history != nil {
+				line += r.formatTrend(r.history.Core(i), 100)
+			}
+			if i < len(cpu.PerCoreFrequency) {
+				line += r.formatCoreFrequency(cpu.PerCoreFrequency[i])
+			}
+			if i < len(cpu.PerCoreBreakdown) {
+				cb := cpu.PerCoreBreakdown[i]
+				line += " " + r.formatCPUBar(cb, 20)
+				if r.shouldWarn(cb.IOWait, r.thresholds.IOWait) || r.shouldWarn(cb.Steal, r.thresholds.Steal) {
+					line += fmt.Sprintf(" iowait %.1f%% steal %.1f%% %s", cb.IOWait, cb.Steal, r.formatWarning())
+				}
+			}
+			output.WriteString(line + "\n")
 		}
 	}
 
 	return output.String()
 }
 
+// formatCoreFrequency formats a core's clock speed and throttle rate
+func (r *TerminalRenderer) formatCoreFrequency(freq models.CoreFrequency) string {
+	var out string
+	if freq.CurrentMHz > 0 {
+		out = fmt.Sprintf(" %5.0f MHz", freq.CurrentMHz)
+	}
+
+	if rate := freq.ThrottleRate + freq.PackageThrottleRate; rate > 0 {
+		throttle := fmt.Sprintf(" throttled %.1f/s %s", rate, r.formatWarning())
+		if r.useANSI {
+			throttle = color.RedString(throttle)
+		}
+		out += throttle
+	}
+
+	return out
+}
+
+// cpuModeSegments defines the order, symbol and color of each mode in the
+// stacked CPU breakdown bar. Idle time is left blank.
+var cpuModeSegments = []struct {
+	symbol string
+	attr   color.Attribute
+	value  func(b models.CPUBreakdown) float64
+}{
+	{"u", color.FgGreen, func(b models.CPUBreakdown) float64 { return b.User }},
+	{"n", color.FgBlue, func(b models.CPUBreakdown) float64 { return b.Nice }},
+	{"s", color.FgRed, func(b models.CPUBreakdown) float64 { return b.System }},
+	{"i", color.FgYellow, func(b models.CPUBreakdown) float64 { return b.IRQ + b.SoftIRQ }},
+	{"w", color.FgMagenta, func(b models.CPUBreakdown) float64 { return b.IOWait }},
+	{"t", color.FgCyan, func(b models.CPUBreakdown) float64 { return b.Steal }},
+}
+
+// formatCPUBar draws a stacked bar of the given width showing the time spent
+// in each CPU mode (u=user n=nice s=system i=irq w=iowait t=steal)
+func (r *TerminalRenderer) formatCPUBar(b models.CPUBreakdown, width int) string {
+	var bar strings.Builder
+	used := 0
+	acc := 0.0
+
+	for _, seg := range cpuModeSegments {
+		acc += seg.value(b)
+		// Round cumulative totals so segments always add up to the bar width
+		end := int(acc/100.0*float64(width) + 0.5)
+		if end > width {
+			end = width
+		}
+		if end <= used {
+			continue
+		}
+		cells := strings.Repeat(seg.symbol, end-used)
+		if r.useANSI {
+			cells = color.New(seg.attr).Sprint(cells)
+		}
+		bar.WriteString(cells)
+		used = end
+	}
+
+	bar.WriteString(strings.Repeat(" ", width-used))
+	return "[" + bar.String() + "]"
+}
+
+// modeWarning returns a warning suffix when a CPU mode exceeds its threshold
+func (r *TerminalRenderer) modeWarning(value, threshold float64) string {
+	if r.shouldWarn(value, threshold) {
+		return " " + r.formatWarning()
+	}
+	return ""
+}
+
 // formatMemory formats memory statistics
 func (r *TerminalRenderer) formatMemory(mem models.MemoryStats) string {
 	var output strings.Builder
@@ -167,12 +648,253 @@ func (r *TerminalRenderer) formatMemory(mem models.MemoryStats) string {
 	if warning {
 		percentStr += " " + r.formatWarning()
 	}
-	output.WriteString(r.colorizeValue(percentStr, mem.Percent, r.thresholds.Memory) + "\n")
+	percentStr = r.colorizeValue(percentStr, mem.Percent, r.thresholds.Memory)
+	if r.history != nil {
+		percentStr += r.formatGauge(mem.Percent, r.thresholds.Memory) + r.formatTrend(r.history.Memory(), 100)
+	}
+	output.WriteString(percentStr + "\n")
 
 	output.WriteString(fmt.Sprintf("  Total:     %8.2f GB\n", totalGB))
 	output.WriteString(fmt.Sprintf("  Used:      %8.2f GB\n", usedGB))
 	output.WriteString(fmt.Sprintf("  Available: %8.2f GB\n", availGB))
 
+	// Breakdown
+	output.WriteString(fmt.Sprintf("  Cached:    %s  Buffers: %s  Dirty: %s\n",
+		formatBytes(mem.Cached), formatBytes(mem.Buffers), formatBytes(mem.Dirty)))
+	output.WriteString(fmt.Sprintf("  Shmem:     %s  Slab: %s reclaimable, %s unreclaimable\n",
+		formatBytes(mem.Shmem), formatBytes(mem.SlabReclaimable), formatBytes(mem.SlabUnreclaimable)))
+	if mem.HugePagesTotal > 0 {
+		output.WriteString(fmt.Sprintf("  HugePages: %d/%d free (%s each)\n",
+			mem.HugePagesFree, mem.HugePagesTotal, formatBytes(mem.HugePageSize)))
+	}
+
+	// Swap sub-section
+	output.WriteString("  Swap:\n")
+	if mem.Swap.Total == 0 {
+		output.WriteString("    (no swap configured)\n")
+		return output.String()
+	}
+
+	swapTotalGB := float64(mem.Swap.Total) / (1024 * 1024 * 1024)
+	swapUsedGB := float64(mem.Swap.Used) / (1024 * 1024 * 1024)
+	swapFreeGB := float64(mem.Swap.Free) / (1024 * 1024 * 1024)
+
+	warning = r.shouldWarn(mem.Swap.Percent, r.thresholds.Swap)
+	swapStr := fmt.Sprintf("    Usage:     %6.2f%%", mem.Swap.Percent)
+	if warning {
+		swapStr += " " + r.formatWarning()
+	}
+	output.WriteString(r.colorizeValue(swapStr, mem.Swap.Percent, r.thresholds.Swap) + "\n")
+
+	output.WriteString(fmt.Sprintf("    Total:     %8.2f GB\n", swapTotalGB))
+	output.WriteString(fmt.Sprintf("    Used:      %8.2f GB\n", swapUsedGB))
+	output.WriteString(fmt.Sprintf("    Free:      %8.2f GB\n", swapFreeGB))
+
+	return output.String()
+}
+
+// formatPressure formats Pressure Stall Information
+func (r *TerminalRenderer) formatPressure(psi models.PressureStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Pressure Stall (PSI):")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Pressure Stall (PSI):\n")
+	}
+
+	if !psi.Available {
+		output.WriteString("  (not supported by this kernel)\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("  %-8s %-4s %7s %7s %7s %10s\n",
+		"", "", "avg10", "avg60", "avg300", "total"))
+
+	resources := []struct {
+		name      string
+		res       models.PressureResource
+		threshold float64
+	}{
+		{"CPU", psi.CPU, r.thresholds.PressureCPU},
+		{"Memory", psi.Memory, r.thresholds.PressureMemory},
+		{"IO", psi.IO, r.thresholds.PressureIO},
+	}
+
+	for _, res := range resources {
+		some := res.res.Some
+		someStr := fmt.Sprintf("  %-8s %-4s %6.2f%% %6.2f%% %6.2f%% %10s",
+			res.name, "some", some.Avg10, some.Avg60, some.Avg300, some.Total.Round(time.Millisecond))
+		if r.shouldWarn(some.Avg10, res.threshold) {
+			someStr += " " + r.formatWarning()
+		}
+		output.WriteString(r.colorizeValue(someStr, some.Avg10, res.threshold) + "\n")
+
+		full := res.res.Full
+		output.WriteString(fmt.Sprintf("  %-8s %-4s %6.2f%% %6.2f%% %6.2f%% %10s\n",
+			"", "full", full.Avg10, full.Avg60, full.Avg300, full.Total.Round(time.Millisecond)))
+	}
+
+	return output.String()
+}
+
+// formatActivity formats vmstat-style kernel activity rates
+func (r *TerminalRenderer) formatActivity(activity models.ActivityStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("System Activity:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("System Activity:\n")
+	}
+
+	output.WriteString(fmt.Sprintf("  Context switches: %.1f/s  Interrupts: %.1f/s  Forks: %.1f/s\n",
+		activity.ContextSwitchRate, activity.InterruptRate, activity.ForkRate))
+	output.WriteString(fmt.Sprintf("  Tasks:       %d running, %d blocked\n",
+		activity.ProcsRunning, activity.ProcsBlocked))
+	output.WriteString(fmt.Sprintf("  Page faults: %.1f/s (%.1f/s major)\n",
+		activity.PageFaultRate, activity.MajorPageFaultRate))
+	output.WriteString(fmt.Sprintf("  Paging:      %s/s in, %s/s out\n",
+		formatBytes(uint64(activity.PageInRate*1024)), formatBytes(uint64(activity.PageOutRate*1024))))
+	output.WriteString(fmt.Sprintf("  Swapping:    %.1f/s in, %.1f/s out (pages)\n",
+		activity.SwapInRate, activity.SwapOutRate))
+	output.WriteString(fmt.Sprintf("  OOM kills:   %d since boot\n", activity.OOMKills))
+
+	return output.String()
+}
+
+// formatKernelTables formats file handle, PID and inode cache usage
+func (r *TerminalRenderer) formatKernelTables(kernel models.KernelTableStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Kernel Tables:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Kernel Tables:\n")
+	}
+
+	output.WriteString(r.formatTableUsage("Files:  ", kernel.FilesPercent,
+		kernel.FilesAllocated, kernel.FilesMax, r.thresholds.FileDescriptors))
+	if kernel.PIDMax > 0 {
+		output.WriteString(r.formatTableUsage("PIDs:   ", kernel.PIDPercent,
+			kernel.Tasks, kernel.PIDMax, r.thresholds.PIDs))
+	}
+	if kernel.ThreadsMax > 0 {
+		output.WriteString(r.formatTableUsage("Threads:", kernel.ThreadPercent,
+			kernel.Tasks, kernel.ThreadsMax, r.thresholds.PIDs))
+	}
+	if kernel.InodesAllocated > 0 {
+		output.WriteString(fmt.Sprintf("  Inodes:   %d cached, %d unused\n",
+			kernel.InodesAllocated, kernel.InodesFree))
+	}
+
+	return output.String()
+}
+
+// formatTableUsage formats a single kernel table usage line
+func (r *TerminalRenderer) formatTableUsage(label string, percent float64, used, limit uint64, threshold float64) string {
+	line := fmt.Sprintf("  %s %6.2f%% (%d/%d)", label, percent, used, limit)
+	if r.shouldWarn(percent, threshold) {
+		line += " " + r.formatWarning()
+	}
+	return r.colorizeValue(line, percent, threshold) + "\n"
+}
+
+// formatCgroup formats cgroup v2 resource accounting
+func (r *TerminalRenderer) formatCgroup(cg models.CgroupStats) string {
+	var output strings.Builder
+
+	// Section header
+	title := fmt.Sprintf("Cgroup: %s", cg.Path)
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint(title)
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString(title + "\n")
+	}
+
+	// CPU relative to the quota
+	limit := "unlimited"
+	if cg.CPULimit > 0 {
+		limit = fmt.Sprintf("%.2f cores", cg.CPULimit)
+	}
+	cpuStr := fmt.Sprintf("  CPU:       %6.2f%% of %s", cg.CPUPercent, limit)
+	if r.shouldWarn(cg.CPUPercent, r.thresholds.CPU) {
+		cpuStr += " " + r.formatWarning()
+	}
+	output.WriteString(r.colorizeValue(cpuStr, cg.CPUPercent, r.thresholds.CPU) + "\n")
+	if cg.NrPeriods > 0 {
+		output.WriteString(fmt.Sprintf("  Throttled: %d/%d periods (%s)\n",
+			cg.NrThrottled, cg.NrPeriods, cg.ThrottledTime.Round(time.Millisecond)))
+	}
+
+	// Memory relative to memory.max
+	if cg.MemoryMax > 0 {
+		memStr := fmt.Sprintf("  Memory:    %6.2f%% (%s of %s)",
+			cg.MemoryPercent, formatBytes(cg.MemoryCurrent), formatBytes(cg.MemoryMax))
+		if r.shouldWarn(cg.MemoryPercent, r.thresholds.Memory) {
+			memStr += " " + r.formatWarning()
+		}
+		output.WriteString(r.colorizeValue(memStr, cg.MemoryPercent, r.thresholds.Memory) + "\n")
+	} else {
+		output.WriteString(fmt.Sprintf("  Memory:    %s (unlimited)\n", formatBytes(cg.MemoryCurrent)))
+	}
+
+	ev := cg.MemoryEvents
+	eventStr := fmt.Sprintf("  Events:    high %d  max %d  oom %d  oom_kill %d", ev.High, ev.Max, ev.OOM, ev.OOMKill)
+	if ev.OOMKill > 0 && r.useANSI {
+		eventStr = color.RedString(eventStr)
+	}
+	output.WriteString(eventStr + "\n")
+
+	for _, dev := range cg.IO {
+		output.WriteString(fmt.Sprintf("  IO %-7s read %s (%d ops)  write %s (%d ops)\n",
+			dev.Device, formatBytes(dev.ReadBytes), dev.ReadIOs, formatBytes(dev.WriteBytes), dev.WriteIOs))
+	}
+
+	return output.String()
+}
+
+// formatSensors formats temperature and fan readings, colouring each
+// temperature relative to its critical threshold
+func (r *TerminalRenderer) formatSensors(sensors models.SensorStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Sensors:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Sensors:\n")
+	}
+
+	for _, temp := range sensors.Temperatures {
+		crit := r.thresholds.SensorCritical(temp.Name(), temp.Critical)
+		tempStr := fmt.Sprintf("  %-32s %6.1f°C (crit %.1f°C)", truncate(temp.ID(), 32), temp.Current, crit)
+		if r.shouldWarn(temp.Current, crit) {
+			tempStr += " " + r.formatWarning()
+		}
+		output.WriteString(r.colorizeValue(tempStr, temp.Current, crit) + "\n")
+	}
+
+	for _, fan := range sensors.Fans {
+		name := fan.Chip + "/" + fan.Label
+		fanStr := fmt.Sprintf("  %-32s %6d RPM", truncate(name, 32), fan.RPM)
+		if fan.Min > 0 && fan.RPM < fan.Min {
+			fanStr += fmt.Sprintf(" (min %d) %s", fan.Min, r.formatWarning())
+			if r.useANSI {
+				fanStr = color.RedString(fanStr)
+			}
+		}
+		output.WriteString(fanStr + "\n")
+	}
+
 	return output.String()
 }
 
@@ -180,15 +902,18 @@ func (r *TerminalRenderer) formatMemory(mem models.MemoryStats) string {
 func (r *TerminalRenderer) formatDisk(disks []models.DiskStats) string {
 	var output strings.Builder
 
+	start, end := r.view.window(r.view.DiskOffset, len(disks), r.scrollRows)
+	scroll := r.formatScroll(start, end, len(disks), SectionDisk)
+
 	// Section header
 	if r.useANSI {
 		header := color.New(color.FgYellow, color.Bold).Sprint("Disk Usage:")
-		output.WriteString(header + "\n")
+		output.WriteString(header + scroll + "\n")
 	} else {
-		output.WriteString("Disk Usage:\n")
+		output.WriteString("Disk Usage:" + scroll + "\n")
 	}
 
-	for _, disk := range disks {
+	for _, disk := range disks[start:end] {
 		totalGB := float64(disk.Total) / (1024 * 1024 * 1024)
 		usedGB := float64(disk.Used) / (1024 * 1024 * 1024)
 		availGB := float64(disk.Available) / (1024 * 1024 * 1024)
@@ -200,11 +925,49 @@ func (r *TerminalRenderer) formatDisk(disks []models.DiskStats) string {
 		if warning {
 			percentStr += " " + r.formatWarning()
 		}
-		output.WriteString(r.colorizeValue(percentStr, disk.Percent, r.thresholds.Disk) + "\n")
+		percentStr = r.colorizeValue(percentStr, disk.Percent, r.thresholds.Disk)
+		if r.history != nil {
+			percentStr += r.formatGauge(disk.Percent, r.thresholds.Disk) + r.formatTrend(r.history.Disk(disk.Mountpoint), 100)
+		}
+		output.WriteString(percentStr + "\n")
 
 		output.WriteString(fmt.Sprintf("    Total:     %8.2f GB\n", totalGB))
 		output.WriteString(fmt.Sprintf("    Used:      %8.2f GB\n", usedGB))
 		output.WriteString(fmt.Sprintf("    Available: %8.2f GB\n", availGB))
+
+		if disk.InodesTotal > 0 {
+			warning := r.shouldWarn(disk.InodesPercent, r.thresholds.Inodes)
+			inodeStr := fmt.Sprintf("    Inodes:    %6.2f%% (%d/%d)",
+				disk.InodesPercent, disk.InodesUsed, disk.InodesTotal)
+			if warning {
+				inodeStr += " " + r.formatWarning()
+			}
+			output.WriteString(r.colorizeValue(inodeStr, disk.InodesPercent, r.thresholds.Inodes) + "\n")
+		}
+	}
+
+	return output.String()
+}
+
+// formatDiskIO formats block device I/O statistics
+func (r *TerminalRenderer) formatDiskIO(devices []models.DiskIOStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Disk I/O:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Disk I/O:\n")
+	}
+
+	for _, dev := range devices {
+		output.WriteString(fmt.Sprintf("  %s\n", dev.Device))
+		output.WriteString(fmt.Sprintf("    Read:   %s/s (%.1f IOPS)\n",
+			formatBytes(uint64(dev.ReadRate)), dev.ReadIOPS))
+		output.WriteString(fmt.Sprintf("    Write:  %s/s (%.1f IOPS)\n",
+			formatBytes(uint64(dev.WriteRate)), dev.WriteIOPS))
+		output.WriteString(fmt.Sprintf("    Await:  %.2f ms  Util: %6.2f%%\n", dev.AwaitMs, dev.Util))
 	}
 
 	return output.String()
@@ -214,25 +977,201 @@ func (r *TerminalRenderer) formatDisk(disks []models.DiskStats) string {
 func (r *TerminalRenderer) formatNetwork(networks []models.NetworkStats) string {
 	var output strings.Builder
 
+	start, end := r.view.window(r.view.NetworkOffset, len(networks), r.scrollRows)
+	scroll := r.formatScroll(start, end, len(networks), SectionNetwork)
+
 	// Section header
 	if r.useANSI {
 		header := color.New(color.FgYellow, color.Bold).Sprint("Network I/O:")
-		output.WriteString(header + "\n")
+		output.WriteString(header + scroll + "\n")
 	} else {
-		output.WriteString("Network I/O:\n")
+		output.WriteString("Network I/O:" + scroll + "\n")
 	}
 
-	for _, net := range networks {
+	for _, net := range networks[start:end] {
 		output.WriteString(fmt.Sprintf("  %s\n", net.Interface))
-		output.WriteString(fmt.Sprintf("    Sent:     %s (%s/s)\n",
-			formatBytes(net.BytesSent), formatBytes(uint64(net.SendRate))))
-		output.WriteString(fmt.Sprintf("    Received: %s (%s/s)\n",
-			formatBytes(net.BytesRecv), formatBytes(uint64(net.RecvRate))))
+		sent := fmt.Sprintf("    Sent:     %s (%s/s)",
+			formatBytes(net.BytesSent), formatBytes(uint64(net.SendRate)))
+		received := fmt.Sprintf("    Received: %s (%s/s)",
+			formatBytes(net.BytesRecv), formatBytes(uint64(net.RecvRate)))
+		if r.history != nil {
+			// Rates have no fixed maximum, so each trend is scaled to
+			// the busiest sample in its window
+			sent = fmt.Sprintf("%-36s%s", sent, r.formatTrend(r.history.NetTx(net.Interface), 0))
+			received = fmt.Sprintf("%-36s%s", received, r.formatTrend(r.history.NetRx(net.Interface), 0))
+		}
+		output.WriteString(sent + "\n")
+		output.WriteString(received + "\n")
+		output.WriteString(fmt.Sprintf("    Packets:  %.1f/s in, %.1f/s out\n",
+			net.PacketRecvRate, net.PacketSendRate))
+
+		limits := r.thresholds.ForInterface(net.Interface)
+
+		errorRate := net.ErrorRate()
+		errorStr := fmt.Sprintf("    Errors:   %.1f/s in, %.1f/s out (total %d/%d)",
+			net.ErrorRecvRate, net.ErrorSendRate, net.ErrorsRecv, net.ErrorsSent)
+		if r.shouldWarn(errorRate, limits.Errors) {
+			errorStr += " " + r.formatWarning()
+		}
+		output.WriteString(r.colorizeValue(errorStr, errorRate, limits.Errors) + "\n")
+
+		dropRate := net.DropRate()
+		dropStr := fmt.Sprintf("    Drops:    %.1f/s in, %.1f/s out (total %d/%d)",
+			net.DropRecvRate, net.DropSendRate, net.DropsRecv, net.DropsSent)
+		if r.shouldWarn(dropRate, limits.Drops) {
+			dropStr += " " + r.formatWarning()
+		}
+		output.WriteString(r.colorizeValue(dropStr, dropRate, limits.Drops) + "\n")
+
+		// Link faults are shown only while they occur, since they are
+		// counted in the errors above
+		if net.FifoRate > 0 || net.FrameRate > 0 || net.CarrierRate > 0 {
+			output.WriteString(fmt.Sprintf("    Faults:   %.1f/s fifo, %.1f/s frame, %.1f/s carrier\n",
+				net.FifoRate, net.FrameRate, net.CarrierRate))
+		}
+	}
+
+	return output.String()
+}
+
+// formatSockets formats the TCP/UDP socket summary
+func (r *TerminalRenderer) formatSockets(sockets models.SocketStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Sockets:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Sockets:\n")
+	}
+
+	output.WriteString(fmt.Sprintf("  TCP: %d  UDP: %d  Orphans: %d  Memory: TCP %s, UDP %s\n",
+		sockets.TCPTotal, sockets.UDPTotal, sockets.TCPOrphans,
+		formatBytes(sockets.TCPMemory), formatBytes(sockets.UDPMemory)))
+
+	// TCP states in kernel order, omitting empty states
+	var states []string
+	for _, name := range models.TCPStateNames {
+		if count := sockets.TCPStates[name]; count > 0 {
+			states = append(states, fmt.Sprintf("%s %d", name, count))
+		}
+	}
+	if len(states) > 0 {
+		output.WriteString("  States: " + strings.Join(states, "  ") + "\n")
+	}
+
+	if sockets.EphemeralPortsTotal > 0 {
+		output.WriteString(fmt.Sprintf("  Ephemeral ports: %d/%d (%.2f%%)\n",
+			sockets.EphemeralPortsUsed, sockets.EphemeralPortsTotal, sockets.EphemeralPortsPercent))
+	}
+
+	if len(sockets.Listening) > 0 {
+		output.WriteString("  Listening:\n")
+		for _, l := range sockets.Listening {
+			output.WriteString(fmt.Sprintf("    %-5s %s\n", l.Protocol, net.JoinHostPort(l.Address, strconv.Itoa(int(l.Port)))))
+		}
+	}
+
+	return output.String()
+}
+
+// formatContainers formats per-container resource usage as a table
+func (r *TerminalRenderer) formatContainers(containers []models.ContainerStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Containers:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Containers:\n")
+	}
+
+	// Containers arrive sorted by name; interactive mode follows the
+	// process table order instead
+	if r.view.Interactive {
+		containers = sortContainers(containers, r.view.Sort)
+	}
+
+	output.WriteString(fmt.Sprintf("  %-20s %6s %10s %6s %23s %23s %5s\n",
+		"NAME", "CPU%", "MEM", "MEM%", "NET RX/TX /s", "BLOCK R/W /s", "PIDS"))
+
+	for _, ctr := range containers {
+		memPercent := fmt.Sprintf("%6.1f", ctr.MemoryPercent)
+		line := fmt.Sprintf("  %-20s %6.1f %10s %s %23s %23s %5d",
+			truncate(ctr.Name, 20), ctr.CPUPercent, formatBytes(ctr.MemoryUsage),
+			r.colorizeValue(memPercent, ctr.MemoryPercent, r.thresholds.Memory),
+			formatBytes(uint64(ctr.NetRxRate))+"/"+formatBytes(uint64(ctr.NetTxRate)),
+			formatBytes(uint64(ctr.BlockReadRate))+"/"+formatBytes(uint64(ctr.BlockWriteRate)),
+			ctr.PIDs)
+		output.WriteString(line + "\n")
+	}
+
+	return output.String()
+}
+
+// sortContainers returns a copy of containers ordered by CPU or memory
+// usage, highest first
+func sortContainers(containers []models.ContainerStats, sortBy string) []models.ContainerStats {
+	sorted := make([]models.ContainerStats, len(containers))
+	copy(sorted, containers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sortBy == config.ProcessSortMemory {
+			return sorted[i].MemoryUsage > sorted[j].MemoryUsage
+		}
+		return sorted[i].CPUPercent > sorted[j].CPUPercent
+	})
+	return sorted
+}
+
+// processColumnsWidth is the width of the process table columns before
+// the command
+const processColumnsWidth = 54
+
+// formatProcesses formats the top processes as a table
+func (r *TerminalRenderer) formatProcesses(procs []models.ProcessStats) string {
+	var output strings.Builder
+
+	// Section header
+	if r.useANSI {
+		header := color.New(color.FgYellow, color.Bold).Sprint("Processes:")
+		output.WriteString(header + "\n")
+	} else {
+		output.WriteString("Processes:\n")
+	}
+
+	output.WriteString(fmt.Sprintf("  %7s %-10s %1s %4s %6s %6s %10s  %s\n",
+		"PID", "USER", "S", "THR", "CPU%", "MEM%", "RSS", "COMMAND"))
+
+	// Commands use the width left by the other columns on a terminal
+	commandWidth := 60
+	if r.screen != nil && r.screen.width > 0 {
+		commandWidth = r.screen.width - processColumnsWidth
+		if commandWidth < 10 {
+			commandWidth = 10
+		}
+	}
+
+	for _, proc := range procs {
+		line := fmt.Sprintf("  %7d %-10s %1s %4d %6.1f %6.1f %10s  %s",
+			proc.PID, truncate(proc.User, 10), proc.State, proc.Threads,
+			proc.CPUPercent, proc.MemPercent, formatBytes(proc.RSS), truncate(proc.Command, commandWidth))
+		output.WriteString(line + "\n")
 	}
 
 	return output.String()
 }
 
+// truncate shortens s to at most n runes, marking the cut with "~"
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "~"
+}
+
 // colorizeValue applies color based on threshold
 func (r *TerminalRenderer) colorizeValue(text string, value, threshold float64) string {
 	if !r.useANSI {
@@ -268,6 +1207,17 @@ func isTerminal(w io.Writer) bool {
 	return false
 }
 
+// formatUptime formats a duration as days, hours and minutes
+func formatUptime(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	if days > 0 {
+		return fmt.Sprintf("%dd %02dh %02dm", days, hours, minutes)
+	}
+	return fmt.Sprintf("%02dh %02dm", hours, minutes)
+}
+
 // formatBytes formats bytes into human-readable format
 func formatBytes(bytes uint64) string {
 	const unit = 1024
@@ -0,0 +1,57 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/alert"
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+func TestFormatAlertsShowsCurrentValue(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Thresholds.Disk = 90
+
+	r := NewTerminalRenderer(&bytes.Buffer{}, &cfg.Thresholds)
+	r.useANSI = true
+	r.RenderAlert(alert.Event{
+		Metric:    config.MetricDisk,
+		Instance:  "/",
+		State:     alert.StateFiring,
+		Value:     95,
+		Threshold: 90,
+		Since:     time.Now(),
+	})
+
+	tests := []struct {
+		name string
+		disk []models.DiskStats
+		want string
+	}{
+		{"still above", []models.DiskStats{{Mountpoint: "/", Percent: 97}}, "97.00 > 90.00"},
+		{"below threshold", []models.DiskStats{{Mountpoint: "/", Percent: 88}}, "88.00 ≤ 90.00"},
+		{"instance gone", nil, "triggered at 95.00 > 90.00"},
+	}
+
+	for _, tt := range tests {
+		got := r.formatAlerts(&models.Metrics{Disk: tt.disk})
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: alerts = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHelpListsKeys(t *testing.T) {
+	r := NewTerminalRenderer(&bytes.Buffer{}, &config.NewDefaultConfig().Thresholds)
+	help := r.formatHelp()
+
+	// Every key the controller handles is listed
+	for _, key := range []string{"q, Ctrl+C", "space", "+ / -", "c m d n p", "tab", "↑ ↓ / k j", "PgUp PgDn", "s", "h, ?"} {
+		if !strings.Contains(help, "  "+key+" ") {
+			t.Errorf("help does not list %q:\n%s", key, help)
+		}
+	}
+}
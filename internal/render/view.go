@@ -0,0 +1,82 @@
+package render
+
+import "time"
+
+// Section identifies a part of the terminal display that can be hidden
+type Section int
+
+// Sections that can be toggled in interactive mode
+const (
+	SectionCPU Section = iota
+	SectionMemory
+	SectionDisk
+	SectionNetwork
+	SectionProcesses
+
+	sectionCount // Number of sections
+)
+
+// String returns the display name of the section
+func (s Section) String() string {
+	switch s {
+	case SectionCPU:
+		return "CPU"
+	case SectionMemory:
+		return "memory"
+	case SectionDisk:
+		return "disk"
+	case SectionNetwork:
+		return "network"
+	case SectionProcesses:
+		return "processes"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultScrollRows is the number of disks or interfaces shown at once in
+// interactive mode when the terminal height is unknown; longer lists are
+// scrolled. On a terminal the window grows to fill its height.
+const DefaultScrollRows = 4
+
+// View holds the interactive display state applied by TerminalRenderer.
+// The zero value renders everything, as in non-interactive mode.
+type View struct {
+	Interactive bool // Running with keyboard input; enables the status line and scrolling
+
+	// Sections not to render. An array rather than a map, so copies of a
+	// View share no state with the controller that produced them.
+	Hidden [sectionCount]bool
+
+	// Scroll offsets into the disk and interface lists, and which of the
+	// two the arrow keys currently scroll
+	DiskOffset    int
+	NetworkOffset int
+	Focus         Section
+
+	Sort     string        // Table sort order: config.ProcessSortCPU or config.ProcessSortMemory
+	Interval time.Duration // Current refresh interval, shown in the status line
+	Paused   bool          // Display updates are paused
+	ShowHelp bool          // Show the key bindings instead of metrics
+}
+
+// visible reports whether the section should be rendered
+func (v View) visible(s Section) bool {
+	return !v.Hidden[s]
+}
+
+// window returns the range of a list of n items to show from offset,
+// rows at a time, clamping the offset so the last page stays full
+func (v View) window(offset, n, rows int) (start, end int) {
+	if !v.Interactive || n <= rows {
+		return 0, n
+	}
+	start = offset
+	if start > n-rows {
+		start = n - rows
+	}
+	if start < 0 {
+		start = 0
+	}
+	return start, start + rows
+}
@@ -27,6 +27,14 @@ func NewDarwinStatsProvider() *DarwinStatsProvider {
 	return &DarwinStatsProvider{}
 }
 
+// GetHostStats retrieves load average, uptime and task counts
+func (p *DarwinStatsProvider) GetHostStats() (*models.HostStats, error) {
+	// Note: Load average and boot time are exposed via vm.loadavg and
+	// kern.boottime, which return C structs that the simplified sysctl
+	// helpers below cannot decode yet. Return an empty summary for now.
+	return &models.HostStats{}, nil
+}
+
 // GetCPUStats retrieves CPU usage statistics using sysctl
 func (p *DarwinStatsProvider) GetCPUStats() (*models.CPUStats, error) {
 	// Get number of CPUs
@@ -54,10 +62,12 @@ func (p *DarwinStatsProvider) GetCPUStats() (*models.CPUStats, error) {
 	// Calculate overall percentage
 	if p.prevCPUTimes != nil && len(p.prevCPUTimes) > 0 {
 		stats.Overall = calculateCPUPercent(p.prevCPUTimes[0], currentTimes[0])
+		stats.Breakdown = calculateCPUBreakdown(p.prevCPUTimes[0], currentTimes[0])
 
 		// For per-core, use overall as approximation if per-core data unavailable
 		for i := range stats.PerCore {
 			stats.PerCore[i] = stats.Overall
+			stats.PerCoreBreakdown = append(stats.PerCoreBreakdown, stats.Breakdown)
 		}
 	} else {
 		stats.Overall = 0.0
@@ -118,12 +128,21 @@ func (p *DarwinStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 		available := stat.Bavail * uint64(stat.Bsize)
 		used := total - (stat.Bfree * uint64(stat.Bsize))
 
+		// Some filesystems (e.g. btrfs, vfat) report zero inodes
+		inodesTotal := uint64(stat.Files)
+		inodesFree := uint64(stat.Ffree)
+		inodesUsed := inodesTotal - inodesFree
+
 		stats = append(stats, models.DiskStats{
-			Mountpoint: mountpoint,
-			Total:      total,
-			Used:       used,
-			Available:  available,
-			Percent:    models.CalculatePercentage(used, total),
+			Mountpoint:    mountpoint,
+			Total:         total,
+			Used:          used,
+			Available:     available,
+			Percent:       models.CalculatePercentage(used, total),
+			InodesTotal:   inodesTotal,
+			InodesUsed:    inodesUsed,
+			InodesFree:    inodesFree,
+			InodesPercent: models.CalculatePercentage(inodesUsed, inodesTotal),
 		})
 	}
 
@@ -134,6 +153,13 @@ func (p *DarwinStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 	return stats, nil
 }
 
+// GetDiskIOStats retrieves block device I/O statistics
+func (p *DarwinStatsProvider) GetDiskIOStats() ([]models.DiskIOStats, error) {
+	// Note: Per-device I/O counters on macOS are only available through
+	// IOKit (IOBlockStorageDriver statistics). Return empty stats for now.
+	return []models.DiskIOStats{}, nil
+}
+
 // GetNetworkStats retrieves network I/O statistics
 func (p *DarwinStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 	// Note: Getting network stats on macOS requires more complex syscalls
@@ -142,6 +168,53 @@ func (p *DarwinStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 	return []models.NetworkStats{}, nil
 }
 
+// GetPressureStats retrieves Pressure Stall Information
+func (p *DarwinStatsProvider) GetPressureStats() (*models.PressureStats, error) {
+	// PSI is Linux-specific
+	return &models.PressureStats{Available: false}, nil
+}
+
+// GetCgroupStats retrieves cgroup v2 resource accounting
+func (p *DarwinStatsProvider) GetCgroupStats() (*models.CgroupStats, error) {
+	// cgroups are Linux-specific
+	return &models.CgroupStats{Available: false}, nil
+}
+
+// GetSensorStats retrieves hardware temperature and fan readings
+func (p *DarwinStatsProvider) GetSensorStats() (*models.SensorStats, error) {
+	// Note: Sensors on macOS are only reachable through the SMC via IOKit.
+	// Return empty readings for now.
+	return &models.SensorStats{}, nil
+}
+
+// GetSocketStats retrieves a summary of TCP and UDP sockets
+func (p *DarwinStatsProvider) GetSocketStats() (*models.SocketStats, error) {
+	// Note: Socket tables on macOS require the net.inet.tcp.pcblist sysctl,
+	// which returns structs the simplified sysctl helpers cannot decode.
+	return &models.SocketStats{}, nil
+}
+
+// GetActivityStats retrieves kernel activity counters
+func (p *DarwinStatsProvider) GetActivityStats() (*models.ActivityStats, error) {
+	// Note: vm.stats and the Mach host_statistics64 counters needed here
+	// are not exposed through the simplified sysctl helpers.
+	return &models.ActivityStats{}, nil
+}
+
+// GetKernelTableStats retrieves file handle, inode cache and PID usage
+func (p *DarwinStatsProvider) GetKernelTableStats() (*models.KernelTableStats, error) {
+	// Note: kern.num_files, kern.maxfiles and kern.maxproc are not yet
+	// known to the simplified sysctl helpers. Return empty usage for now.
+	return &models.KernelTableStats{}, nil
+}
+
+// GetProcessStats retrieves per-process statistics
+func (p *DarwinStatsProvider) GetProcessStats() ([]models.ProcessStats, error) {
+	// Note: Process enumeration on macOS requires kern.proc sysctls or
+	// libproc, which the simplified sysctl helpers do not support yet.
+	return []models.ProcessStats{}, nil
+}
+
 // Helper functions
 
 func sysctlUint32(name string) (uint32, error) {
@@ -278,3 +351,22 @@ func calculateCPUPercent(prev, curr cpuTime) float64 {
 
 	return (float64(totalDelta-idleDelta) / float64(totalDelta)) * 100.0
 }
+
+// calculateCPUBreakdown computes the percentage of time spent in each mode.
+// kern.cp_time only reports user, system, idle and nice ticks.
+func calculateCPUBreakdown(prev, curr cpuTime) models.CPUBreakdown {
+	prevTotal := prev.user + prev.system + prev.idle + prev.nice
+	currTotal := curr.user + curr.system + curr.idle + curr.nice
+
+	totalDelta := float64(currTotal - prevTotal)
+	if totalDelta == 0 {
+		return models.CPUBreakdown{}
+	}
+
+	return models.CPUBreakdown{
+		User:   float64(curr.user-prev.user) / totalDelta * 100.0,
+		Nice:   float64(curr.nice-prev.nice) / totalDelta * 100.0,
+		System: float64(curr.system-prev.system) / totalDelta * 100.0,
+		Idle:   float64(curr.idle-prev.idle) / totalDelta * 100.0,
+	}
+}
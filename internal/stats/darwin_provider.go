@@ -4,6 +4,6 @@ package stats
 
 import "github.com/sysmon/system-monitor-cli/internal/collector"
 
-func newPlatformProvider() collector.SystemStatsProvider {
+func newPlatformProvider(opts Options) collector.SystemStatsProvider {
 	return NewDarwinStatsProvider()
 }
@@ -4,60 +4,162 @@ package stats
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/sysmon/system-monitor-cli/internal/counter"
 	"github.com/sysmon/system-monitor-cli/internal/models"
 )
 
+// diskSectorSize is the unit used for sector counts in /proc/diskstats,
+// which is always 512 bytes regardless of the device's physical sector size
+const diskSectorSize = 512
+
 // LinuxStatsProvider implements SystemStatsProvider for Linux systems
 type LinuxStatsProvider struct {
-	prevCPUTimes []cpuTime
+	procRoot         string // Root of the procfs mount, normally /proc
+	sysRoot          string // Root of the sysfs mount, normally /sys
+	hostRoot         string // Where the host's root filesystem is mounted, normally /
+	prevCPUTimes     []cpuTime
+	cgroupPath       string               // Cgroup to account, empty for the current process's cgroup
+	prevThrottle     map[string][2]uint64 // Core and package throttle counts by core name
+	prevThrottleTime time.Time
+	userNames        map[string]string // UID to user name cache
+
+	// Activity counters from the last GetCPUStats pass over /proc/stat,
+	// nil once GetActivityStats has used them
+	statCounters *models.ActivityStats
 }
 
 type cpuTime struct {
-	user    uint64
-	nice    uint64
-	system  uint64
-	idle    uint64
-	iowait  uint64
-	irq     uint64
-	softirq uint64
+	name      string // "cpu" for the aggregate line, "cpuN" for each core
+	user      uint64
+	nice      uint64
+	system    uint64
+	idle      uint64
+	iowait    uint64
+	irq       uint64
+	softirq   uint64
+	steal     uint64
+	guest     uint64
+	guestNice uint64
 }
 
-// NewLinuxStatsProvider creates a new Linux stats provider
-func NewLinuxStatsProvider() *LinuxStatsProvider {
-	return &LinuxStatsProvider{}
+// NewLinuxStatsProvider creates a new Linux stats provider reading from
+// the given procfs and sysfs roots. Empty roots default to /proc and /sys,
+// which allows monitoring the host from a container with /host/proc mounted
+// or pointing the provider at a fixture tree in tests.
+func NewLinuxStatsProvider(procRoot, sysRoot string) *LinuxStatsProvider {
+	if procRoot == "" {
+		procRoot = DefaultProcRoot
+	}
+	if sysRoot == "" {
+		sysRoot = DefaultSysRoot
+	}
+	return &LinuxStatsProvider{
+		procRoot:  procRoot,
+		sysRoot:   sysRoot,
+		hostRoot:  DefaultHostRoot,
+		userNames: make(map[string]string),
+	}
+}
+
+// GetHostStats retrieves load average and task counts from /proc/loadavg
+// and system uptime from /proc/uptime
+func (p *LinuxStatsProvider) GetHostStats() (*models.HostStats, error) {
+	loadavgPath := p.procPath("loadavg")
+	loadavg, err := os.ReadFile(loadavgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", loadavgPath, err)
+	}
+
+	// Format: "0.20 0.18 0.12 1/80 11206"
+	fields := strings.Fields(string(loadavg))
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("unexpected %s format: %q", loadavgPath, string(loadavg))
+	}
+
+	var stats models.HostStats
+	stats.Load1 = parseFloat64(fields[0])
+	stats.Load5 = parseFloat64(fields[1])
+	stats.Load15 = parseFloat64(fields[2])
+
+	if running, total, ok := strings.Cut(fields[3], "/"); ok {
+		stats.RunningTasks = parseUint64(running)
+		stats.TotalTasks = parseUint64(total)
+	}
+
+	uptimePath := p.procPath("uptime")
+	uptime, err := os.ReadFile(uptimePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uptimePath, err)
+	}
+
+	// Format: "350735.47 234388.90" (seconds since boot, idle seconds)
+	fields = strings.Fields(string(uptime))
+	if len(fields) < 1 {
+		return nil, fmt.Errorf("unexpected %s format: %q", uptimePath, string(uptime))
+	}
+	stats.Uptime = time.Duration(parseFloat64(fields[0]) * float64(time.Second))
+
+	return &stats, nil
+}
+
+// SetCgroupPath sets the cgroup v2 path (relative to the cgroup2 mount)
+// used by GetCgroupStats. An empty path selects the cgroup of the current
+// process.
+func (p *LinuxStatsProvider) SetCgroupPath(path string) {
+	p.cgroupPath = path
+}
+
+// SetHostRoot sets where the host's root filesystem is mounted, e.g.
+// /host inside a container. Mountpoints read from the host's mount table
+// are resolved below it for GetDiskStats. An empty root selects /.
+func (p *LinuxStatsProvider) SetHostRoot(root string) {
+	if root == "" {
+		root = DefaultHostRoot
+	}
+	p.hostRoot = root
 }
 
 // GetCPUStats retrieves CPU usage statistics from /proc/stat
 func (p *LinuxStatsProvider) GetCPUStats() (*models.CPUStats, error) {
-	file, err := os.Open("/proc/stat")
+	path := p.procPath("stat")
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open /proc/stat: %w", err)
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	var currentTimes []cpuTime
 	var stats models.CPUStats
+	var counters models.ActivityStats
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		fields := strings.Fields(line)
+
+		// The other lines hold activity counters, kept for
+		// GetActivityStats so it need not read the file again
 		if !strings.HasPrefix(line, "cpu") {
+			parseStatCounter(fields, &counters)
 			continue
 		}
-
-		fields := strings.Fields(line)
 		if len(fields) < 8 {
 			continue
 		}
 
 		// Parse CPU times
 		times := cpuTime{
+			name:    fields[0],
 			user:    parseUint64(fields[1]),
 			nice:    parseUint64(fields[2]),
 			system:  parseUint64(fields[3]),
@@ -67,48 +169,68 @@ func (p *LinuxStatsProvider) GetCPUStats() (*models.CPUStats, error) {
 			softirq: parseUint64(fields[7]),
 		}
 
+		// steal, guest and guest_nice were added in later kernels
+		if len(fields) > 8 {
+			times.steal = parseUint64(fields[8])
+		}
+		if len(fields) > 9 {
+			times.guest = parseUint64(fields[9])
+		}
+		if len(fields) > 10 {
+			times.guestNice = parseUint64(fields[10])
+		}
+
 		currentTimes = append(currentTimes, times)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading /proc/stat: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
 	}
 
 	if len(currentTimes) == 0 {
-		return nil, fmt.Errorf("no CPU data found in /proc/stat")
+		return nil, fmt.Errorf("no CPU data found in %s", path)
 	}
+	p.statCounters = &counters
 
 	// Calculate percentages
 	if p.prevCPUTimes != nil && len(p.prevCPUTimes) == len(currentTimes) {
 		// Overall CPU (first entry)
 		stats.Overall = calculateCPUPercent(p.prevCPUTimes[0], currentTimes[0])
+		stats.Breakdown = calculateCPUBreakdown(p.prevCPUTimes[0], currentTimes[0])
 
 		// Per-core CPU (remaining entries)
 		for i := 1; i < len(currentTimes); i++ {
 			percent := calculateCPUPercent(p.prevCPUTimes[i], currentTimes[i])
 			stats.PerCore = append(stats.PerCore, percent)
+			stats.PerCoreBreakdown = append(stats.PerCoreBreakdown,
+				calculateCPUBreakdown(p.prevCPUTimes[i], currentTimes[i]))
 		}
 	} else {
 		// First run - return zeros
 		stats.Overall = 0.0
 		for i := 1; i < len(currentTimes); i++ {
 			stats.PerCore = append(stats.PerCore, 0.0)
+			stats.PerCoreBreakdown = append(stats.PerCoreBreakdown, models.CPUBreakdown{})
 		}
 	}
 
 	p.prevCPUTimes = currentTimes
+	stats.PerCoreFrequency = p.readCoreFrequencies(currentTimes[1:])
 	return &stats, nil
 }
 
 // GetMemoryStats retrieves memory statistics from /proc/meminfo
 func (p *LinuxStatsProvider) GetMemoryStats() (*models.MemoryStats, error) {
-	file, err := os.Open("/proc/meminfo")
+	path := p.procPath("meminfo")
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open /proc/meminfo: %w", err)
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
 	}
 	defer file.Close()
 
 	var memTotal, memFree, memAvailable, buffers, cached uint64
+	var swapTotal, swapFree, dirty, shmem, sReclaimable, sUnreclaim uint64
+	var hugePagesTotal, hugePagesFree, hugePageSize uint64
 	scanner := bufio.NewScanner(file)
 
 	for scanner.Scan() {
@@ -121,6 +243,11 @@ func (p *LinuxStatsProvider) GetMemoryStats() (*models.MemoryStats, error) {
 		key := strings.TrimSuffix(fields[0], ":")
 		value := parseUint64(fields[1]) * 1024 // Convert KB to bytes
 
+		// HugePages_* counters are page counts, not kilobytes
+		if strings.HasPrefix(key, "HugePages_") {
+			value = parseUint64(fields[1])
+		}
+
 		switch key {
 		case "MemTotal":
 			memTotal = value
@@ -132,11 +259,29 @@ func (p *LinuxStatsProvider) GetMemoryStats() (*models.MemoryStats, error) {
 			buffers = value
 		case "Cached":
 			cached = value
+		case "SwapTotal":
+			swapTotal = value
+		case "SwapFree":
+			swapFree = value
+		case "Dirty":
+			dirty = value
+		case "Shmem":
+			shmem = value
+		case "SReclaimable":
+			sReclaimable = value
+		case "SUnreclaim":
+			sUnreclaim = value
+		case "HugePages_Total":
+			hugePagesTotal = value
+		case "HugePages_Free":
+			hugePagesFree = value
+		case "Hugepagesize":
+			hugePageSize = value
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading /proc/meminfo: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
 	}
 
 	// Use MemAvailable if present, otherwise calculate
@@ -146,21 +291,45 @@ func (p *LinuxStatsProvider) GetMemoryStats() (*models.MemoryStats, error) {
 	}
 
 	used := memTotal - available
+	swapUsed := swapTotal - swapFree
 
 	return &models.MemoryStats{
-		Total:     memTotal,
-		Used:      used,
-		Available: available,
-		Percent:   models.CalculatePercentage(used, memTotal),
+		Total:             memTotal,
+		Used:              used,
+		Available:         available,
+		Percent:           models.CalculatePercentage(used, memTotal),
+		Buffers:           buffers,
+		Cached:            cached,
+		Dirty:             dirty,
+		Shmem:             shmem,
+		SlabReclaimable:   sReclaimable,
+		SlabUnreclaimable: sUnreclaim,
+		HugePagesTotal:    hugePagesTotal,
+		HugePagesFree:     hugePagesFree,
+		HugePageSize:      hugePageSize,
+		Swap: models.SwapStats{
+			Total:   swapTotal,
+			Used:    swapUsed,
+			Free:    swapFree,
+			Percent: models.CalculatePercentage(swapUsed, swapTotal),
+		},
 	}, nil
 }
 
 // GetDiskStats retrieves disk usage statistics using syscall.Statfs
 func (p *LinuxStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
-	// Read mounted filesystems from /proc/mounts
-	file, err := os.Open("/proc/mounts")
+	// Read mounted filesystems from the mount table of PID 1, which is in
+	// the host's mount namespace when the host's procfs is mounted, rather
+	// than /proc/mounts, which resolves through self to sysmon's own. Fall
+	// back to the latter if PID 1 cannot be inspected.
+	path := p.procPath("1", "mounts")
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open /proc/mounts: %w", err)
+		path = p.procPath("mounts")
+		file, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
 	}
 	defer file.Close()
 
@@ -190,9 +359,10 @@ func (p *LinuxStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 		}
 		seen[mountpoint] = true
 
-		// Get disk stats
+		// Get disk stats, through the host root so that the host's
+		// filesystems are measured rather than the container's
 		var stat syscall.Statfs_t
-		if err := syscall.Statfs(mountpoint, &stat); err != nil {
+		if err := syscall.Statfs(filepath.Join(p.hostRoot, mountpoint), &stat); err != nil {
 			continue // Skip filesystems we can't stat
 		}
 
@@ -200,17 +370,71 @@ func (p *LinuxStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 		available := stat.Bavail * uint64(stat.Bsize)
 		used := total - (stat.Bfree * uint64(stat.Bsize))
 
+		// Some filesystems (e.g. btrfs, vfat) report zero inodes
+		inodesTotal := uint64(stat.Files)
+		inodesFree := uint64(stat.Ffree)
+		inodesUsed := inodesTotal - inodesFree
+
 		stats = append(stats, models.DiskStats{
-			Mountpoint: mountpoint,
-			Total:      total,
-			Used:       used,
-			Available:  available,
-			Percent:    models.CalculatePercentage(used, total),
+			Mountpoint:    mountpoint,
+			Total:         total,
+			Used:          used,
+			Available:     available,
+			Percent:       models.CalculatePercentage(used, total),
+			InodesTotal:   inodesTotal,
+			InodesUsed:    inodesUsed,
+			InodesFree:    inodesFree,
+			InodesPercent: models.CalculatePercentage(inodesUsed, inodesTotal),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return stats, nil
+}
+
+// GetDiskIOStats retrieves block device I/O counters from /proc/diskstats
+func (p *LinuxStatsProvider) GetDiskIOStats() ([]models.DiskIOStats, error) {
+	path := p.procPath("diskstats")
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var stats []models.DiskIOStats
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		// Format: major minor name reads merged sectors ms writes merged sectors ms inflight io_ms weighted_ms ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 14 {
+			continue
+		}
+
+		device := fields[2]
+
+		// Skip virtual devices
+		if strings.HasPrefix(device, "loop") || strings.HasPrefix(device, "ram") {
+			continue
+		}
+
+		stats = append(stats, models.DiskIOStats{
+			Device:          device,
+			ReadsCompleted:  parseUint64(fields[3]),
+			ReadBytes:       parseUint64(fields[5]) * diskSectorSize,
+			ReadTimeMs:      parseUint64(fields[6]),
+			WritesCompleted: parseUint64(fields[7]),
+			WriteBytes:      parseUint64(fields[9]) * diskSectorSize,
+			WriteTimeMs:     parseUint64(fields[10]),
+			IOTimeMs:        parseUint64(fields[12]),
 		})
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading /proc/mounts: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
 	}
 
 	return stats, nil
@@ -218,9 +442,10 @@ func (p *LinuxStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 
 // GetNetworkStats retrieves network I/O statistics from /proc/net/dev
 func (p *LinuxStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
-	file, err := os.Open("/proc/net/dev")
+	path := p.procPath("net", "dev")
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open /proc/net/dev: %w", err)
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
 	}
 	defer file.Close()
 
@@ -240,7 +465,7 @@ func (p *LinuxStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 
 		iface := strings.TrimSpace(parts[0])
 		fields := strings.Fields(parts[1])
-		if len(fields) < 9 {
+		if len(fields) < 16 {
 			continue
 		}
 
@@ -249,32 +474,146 @@ func (p *LinuxStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 			continue
 		}
 
+		// Receive: bytes packets errs drop fifo frame compressed multicast
+		// Transmit: bytes packets errs drop fifo colls carrier compressed
 		stats = append(stats, models.NetworkStats{
-			Interface: iface,
-			BytesRecv: parseUint64(fields[0]),
-			BytesSent: parseUint64(fields[8]),
-			SendRate:  0, // Rates calculated by collector
-			RecvRate:  0,
+			Interface:      iface,
+			BytesRecv:      parseUint64(fields[0]),
+			PacketsRecv:    parseUint64(fields[1]),
+			ErrorsRecv:     parseUint64(fields[2]),
+			DropsRecv:      parseUint64(fields[3]),
+			FifoRecv:       parseUint64(fields[4]),
+			FrameRecv:      parseUint64(fields[5]),
+			CompressedRecv: parseUint64(fields[6]),
+			MulticastRecv:  parseUint64(fields[7]),
+			BytesSent:      parseUint64(fields[8]),
+			PacketsSent:    parseUint64(fields[9]),
+			ErrorsSent:     parseUint64(fields[10]),
+			DropsSent:      parseUint64(fields[11]),
+			FifoSent:       parseUint64(fields[12]),
+			Collisions:     parseUint64(fields[13]),
+			CarrierSent:    parseUint64(fields[14]),
+			CompressedSent: parseUint64(fields[15]),
+			SendRate:       0, // Rates calculated by collector
+			RecvRate:       0,
 		})
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading /proc/net/dev: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
 	}
 
 	return stats, nil
 }
 
+// GetPressureStats retrieves Pressure Stall Information from
+// /proc/pressure/{cpu,memory,io}. Kernels built without PSI, or booted
+// with psi=0, report Available as false rather than an error.
+func (p *LinuxStatsProvider) GetPressureStats() (*models.PressureStats, error) {
+	var stats models.PressureStats
+
+	resources := []struct {
+		name string
+		dest *models.PressureResource
+	}{
+		{"cpu", &stats.CPU},
+		{"memory", &stats.Memory},
+		{"io", &stats.IO},
+	}
+
+	for _, res := range resources {
+		path := p.procPath("pressure", res.name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP) {
+				return &models.PressureStats{Available: false}, nil
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		*res.dest = parsePressure(string(data))
+	}
+
+	stats.Available = true
+	return &stats, nil
+}
+
 // Helper functions
 
+// procPath joins path elements onto the configured procfs root
+func (p *LinuxStatsProvider) procPath(elem ...string) string {
+	return filepath.Join(append([]string{p.procRoot}, elem...)...)
+}
+
+// sysPath joins path elements onto the configured sysfs root
+func (p *LinuxStatsProvider) sysPath(elem ...string) string {
+	return filepath.Join(append([]string{p.sysRoot}, elem...)...)
+}
+
 func parseUint64(s string) uint64 {
 	val, _ := strconv.ParseUint(s, 10, 64)
 	return val
 }
 
+// parsePressure parses the contents of a /proc/pressure file:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// The cpu file has no "full" line on kernels before 5.13.
+func parsePressure(data string) models.PressureResource {
+	var res models.PressureResource
+
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var dest *models.PressureLine
+		switch fields[0] {
+		case "some":
+			dest = &res.Some
+		case "full":
+			dest = &res.Full
+		default:
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "avg10":
+				dest.Avg10 = parseFloat64(value)
+			case "avg60":
+				dest.Avg60 = parseFloat64(value)
+			case "avg300":
+				dest.Avg300 = parseFloat64(value)
+			case "total":
+				dest.Total = time.Duration(parseUint64(value)) * time.Microsecond
+			}
+		}
+	}
+
+	return res
+}
+
+func parseFloat64(s string) float64 {
+	val, _ := strconv.ParseFloat(s, 64)
+	return val
+}
+
+// total returns the sum of all CPU time fields. Guest time is already
+// included in user and nice by the kernel, so it is not added again.
+func (t cpuTime) total() uint64 {
+	return t.user + t.nice + t.system + t.idle + t.iowait + t.irq + t.softirq + t.steal
+}
+
 func calculateCPUPercent(prev, curr cpuTime) float64 {
-	prevTotal := prev.user + prev.nice + prev.system + prev.idle + prev.iowait + prev.irq + prev.softirq
-	currTotal := curr.user + curr.nice + curr.system + curr.idle + curr.iowait + curr.irq + curr.softirq
+	prevTotal := prev.total()
+	currTotal := curr.total()
 
 	prevIdle := prev.idle + prev.iowait
 	currIdle := curr.idle + curr.iowait
@@ -288,3 +627,29 @@ func calculateCPUPercent(prev, curr cpuTime) float64 {
 
 	return (float64(totalDelta-idleDelta) / float64(totalDelta)) * 100.0
 }
+
+// calculateCPUBreakdown computes the percentage of time spent in each mode
+// between two samples
+func calculateCPUBreakdown(prev, curr cpuTime) models.CPUBreakdown {
+	totalDelta := float64(counter.Delta(prev.total(), curr.total()))
+	if totalDelta == 0 {
+		return models.CPUBreakdown{}
+	}
+
+	pct := func(p, c uint64) float64 {
+		return float64(counter.Delta(p, c)) / totalDelta * 100.0
+	}
+
+	return models.CPUBreakdown{
+		User:      pct(prev.user, curr.user),
+		Nice:      pct(prev.nice, curr.nice),
+		System:    pct(prev.system, curr.system),
+		Idle:      pct(prev.idle, curr.idle),
+		IOWait:    pct(prev.iowait, curr.iowait),
+		IRQ:       pct(prev.irq, curr.irq),
+		SoftIRQ:   pct(prev.softirq, curr.softirq),
+		Steal:     pct(prev.steal, curr.steal),
+		Guest:     pct(prev.guest, curr.guest),
+		GuestNice: pct(prev.guestNice, curr.guestNice),
+	}
+}
@@ -0,0 +1,90 @@
+//go:build linux
+
+package stats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// GetActivityStats retrieves kernel activity counters from the non-CPU
+// lines of /proc/stat and from /proc/vmstat. The /proc/stat counters are
+// taken from the preceding GetCPUStats call when there was one, so a
+// collection reads the file once.
+func (p *LinuxStatsProvider) GetActivityStats() (*models.ActivityStats, error) {
+	var stats models.ActivityStats
+	if p.statCounters != nil {
+		stats = *p.statCounters
+		p.statCounters = nil
+	} else if err := p.readStatCounters(&stats); err != nil {
+		return nil, err
+	}
+
+	// /proc/vmstat may be hidden in restricted containers; the /proc/stat
+	// counters are still worth reporting without it
+	vmstatPath := p.procPath("vmstat")
+	vmstat, err := readKeyValueFile(vmstatPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &stats, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", vmstatPath, err)
+	}
+
+	stats.PageFaults = vmstat["pgfault"]
+	stats.MajorPageFaults = vmstat["pgmajfault"]
+	stats.PagedIn = vmstat["pgpgin"]
+	stats.PagedOut = vmstat["pgpgout"]
+	stats.SwappedIn = vmstat["pswpin"]
+	stats.SwappedOut = vmstat["pswpout"]
+	stats.OOMKills = vmstat["oom_kill"] // Kernel 4.13+
+
+	return &stats, nil
+}
+
+// readStatCounters reads the activity counters from /proc/stat
+func (p *LinuxStatsProvider) readStatCounters(stats *models.ActivityStats) error {
+	path := p.procPath("stat")
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parseStatCounter(strings.Fields(scanner.Text()), stats)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseStatCounter records a /proc/stat activity counter line in stats,
+// ignoring other lines
+func parseStatCounter(fields []string, stats *models.ActivityStats) {
+	if len(fields) < 2 {
+		return
+	}
+
+	// The intr line is followed by per-IRQ counts; only the total is kept
+	switch fields[0] {
+	case "ctxt":
+		stats.ContextSwitches = parseUint64(fields[1])
+	case "intr":
+		stats.Interrupts = parseUint64(fields[1])
+	case "processes":
+		stats.Forks = parseUint64(fields[1])
+	case "procs_running":
+		stats.ProcsRunning = parseUint64(fields[1])
+	case "procs_blocked":
+		stats.ProcsBlocked = parseUint64(fields[1])
+	}
+}
@@ -0,0 +1,180 @@
+//go:build linux
+
+package stats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// GetCgroupStats retrieves resource accounting for a cgroup v2 group from
+// <sysroot>/fs/cgroup. Hosts without a unified cgroup2 hierarchy report
+// Available as false rather than an error.
+func (p *LinuxStatsProvider) GetCgroupStats() (*models.CgroupStats, error) {
+	mount := p.sysPath("fs", "cgroup")
+	if _, err := os.Stat(filepath.Join(mount, "cgroup.controllers")); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &models.CgroupStats{Available: false}, nil
+		}
+		return nil, fmt.Errorf("failed to stat cgroup2 mount: %w", err)
+	}
+
+	path := p.cgroupPath
+	if path == "" {
+		var err error
+		if path, err = p.selfCgroup(); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := filepath.Join(mount, path)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to open cgroup %s: %w", path, err)
+	}
+
+	stats := models.CgroupStats{
+		Available: true,
+		Path:      path,
+	}
+
+	// Controllers may not be enabled for every group, so missing files
+	// are left at their zero values
+	if kv, err := readKeyValueFile(filepath.Join(dir, "cpu.stat")); err == nil {
+		stats.CPUUsage = time.Duration(kv["usage_usec"]) * time.Microsecond
+		stats.CPUUser = time.Duration(kv["user_usec"]) * time.Microsecond
+		stats.CPUSystem = time.Duration(kv["system_usec"]) * time.Microsecond
+		stats.NrPeriods = kv["nr_periods"]
+		stats.NrThrottled = kv["nr_throttled"]
+		stats.ThrottledTime = time.Duration(kv["throttled_usec"]) * time.Microsecond
+	}
+
+	// cpu.max: "$MAX $PERIOD", where $MAX may be "max"
+	if data, err := os.ReadFile(filepath.Join(dir, "cpu.max")); err == nil {
+		fields := strings.Fields(string(data))
+		if len(fields) == 2 && fields[0] != "max" {
+			quota := parseFloat64(fields[0])
+			period := parseFloat64(fields[1])
+			if period > 0 {
+				stats.CPULimit = quota / period
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "memory.current")); err == nil {
+		stats.MemoryCurrent = parseUint64(strings.TrimSpace(string(data)))
+	}
+
+	// memory.max: bytes, or "max" when unlimited
+	if data, err := os.ReadFile(filepath.Join(dir, "memory.max")); err == nil {
+		stats.MemoryMax = parseUint64(strings.TrimSpace(string(data)))
+		stats.MemoryPercent = models.CalculatePercentage(stats.MemoryCurrent, stats.MemoryMax)
+	}
+
+	if kv, err := readKeyValueFile(filepath.Join(dir, "memory.events")); err == nil {
+		stats.MemoryEvents = models.CgroupMemoryEvents{
+			Low:     kv["low"],
+			High:    kv["high"],
+			Max:     kv["max"],
+			OOM:     kv["oom"],
+			OOMKill: kv["oom_kill"],
+		}
+	}
+
+	if io, err := readCgroupIOStat(filepath.Join(dir, "io.stat")); err == nil {
+		stats.IO = io
+	}
+
+	return &stats, nil
+}
+
+// selfCgroup returns the cgroup v2 path of the current process from
+// /proc/self/cgroup, where the unified hierarchy is the "0::" entry
+func (p *LinuxStatsProvider) selfCgroup() (string, error) {
+	path := p.procPath("self", "cgroup")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if cgroup, ok := strings.CutPrefix(line, "0::"); ok {
+			return cgroup, nil
+		}
+	}
+	return "", fmt.Errorf("no cgroup v2 entry in %s", path)
+}
+
+// readKeyValueFile parses files of "key value" lines such as cpu.stat
+// and memory.events
+func readKeyValueFile(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			result[fields[0]] = parseUint64(fields[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readCgroupIOStat parses io.stat lines such as
+// "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0"
+func readCgroupIOStat(path string) ([]models.CgroupIOStats, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var stats []models.CgroupIOStats
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		dev := models.CgroupIOStats{Device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				dev.ReadBytes = parseUint64(value)
+			case "wbytes":
+				dev.WriteBytes = parseUint64(value)
+			case "rios":
+				dev.ReadIOs = parseUint64(value)
+			case "wios":
+				dev.WriteIOs = parseUint64(value)
+			}
+		}
+		stats = append(stats, dev)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
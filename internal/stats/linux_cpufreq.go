@@ -0,0 +1,53 @@
+//go:build linux
+
+package stats
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/counter"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// readCoreFrequencies reads scaling frequencies and thermal throttle
+// counters for each core from /sys/devices/system/cpu/cpuN and computes
+// throttle event rates since the previous call. Cores without cpufreq or
+// thermal_throttle support (common on virtual machines) report zeros.
+func (p *LinuxStatsProvider) readCoreFrequencies(cores []cpuTime) []models.CoreFrequency {
+	now := time.Now()
+	timeDelta := now.Sub(p.prevThrottleTime).Seconds()
+	counts := make(map[string][2]uint64, len(cores))
+	result := make([]models.CoreFrequency, len(cores))
+
+	for i, core := range cores {
+		dir := p.sysPath("devices", "system", "cpu", core.name)
+		freq := &result[i]
+
+		// Frequencies are reported in kHz
+		if khz, ok := readSysInt(filepath.Join(dir, "cpufreq", "scaling_cur_freq")); ok {
+			freq.CurrentMHz = float64(khz) / 1000.0
+		}
+		if khz, ok := readSysInt(filepath.Join(dir, "cpufreq", "scaling_min_freq")); ok {
+			freq.MinMHz = float64(khz) / 1000.0
+		}
+		if khz, ok := readSysInt(filepath.Join(dir, "cpufreq", "scaling_max_freq")); ok {
+			freq.MaxMHz = float64(khz) / 1000.0
+		}
+
+		coreCount, _ := readSysInt(filepath.Join(dir, "thermal_throttle", "core_throttle_count"))
+		pkgCount, _ := readSysInt(filepath.Join(dir, "thermal_throttle", "package_throttle_count"))
+		freq.ThrottleCount = uint64(coreCount)
+		freq.PackageThrottleCount = uint64(pkgCount)
+		counts[core.name] = [2]uint64{freq.ThrottleCount, freq.PackageThrottleCount}
+
+		if prev, ok := p.prevThrottle[core.name]; ok && timeDelta > 0 {
+			freq.ThrottleRate = float64(counter.Delta(prev[0], freq.ThrottleCount)) / timeDelta
+			freq.PackageThrottleRate = float64(counter.Delta(prev[1], freq.PackageThrottleCount)) / timeDelta
+		}
+	}
+
+	p.prevThrottle = counts
+	p.prevThrottleTime = now
+	return result
+}
@@ -0,0 +1,59 @@
+//go:build linux
+
+package stats
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// GetKernelTableStats retrieves file handle usage from /proc/sys/fs/file-nr,
+// the inode cache from /proc/sys/fs/inode-nr and PID usage against
+// /proc/sys/kernel/pid_max and threads-max
+func (p *LinuxStatsProvider) GetKernelTableStats() (*models.KernelTableStats, error) {
+	var stats models.KernelTableStats
+
+	fileNrPath := p.procPath("sys", "fs", "file-nr")
+	data, err := os.ReadFile(fileNrPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fileNrPath, err)
+	}
+
+	// Format: "allocated free max". The free count is always 0 since 2.6,
+	// as the kernel frees unused handles immediately.
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("unexpected %s format: %q", fileNrPath, string(data))
+	}
+	stats.FilesAllocated = parseUint64(fields[0])
+	stats.FilesMax = parseUint64(fields[2])
+	if fileMax := readSysString(p.procPath("sys", "fs", "file-max")); fileMax != "" {
+		stats.FilesMax = parseUint64(fileMax)
+	}
+	stats.FilesPercent = models.CalculatePercentage(stats.FilesAllocated, stats.FilesMax)
+
+	// Format: "nr_inodes nr_free_inodes"
+	if fields := strings.Fields(readSysString(p.procPath("sys", "fs", "inode-nr"))); len(fields) >= 2 {
+		stats.InodesAllocated = parseUint64(fields[0])
+		stats.InodesFree = parseUint64(fields[1])
+	}
+
+	// Every thread holds a PID, so the task count from /proc/loadavg is
+	// what pid_max and threads-max limit
+	if fields := strings.Fields(readSysString(p.procPath("loadavg"))); len(fields) >= 4 {
+		if _, total, ok := strings.Cut(fields[3], "/"); ok {
+			stats.Tasks = parseUint64(total)
+		}
+	}
+
+	stats.PIDMax = parseUint64(readSysString(p.procPath("sys", "kernel", "pid_max")))
+	stats.PIDPercent = models.CalculatePercentage(stats.Tasks, stats.PIDMax)
+
+	stats.ThreadsMax = parseUint64(readSysString(p.procPath("sys", "kernel", "threads-max")))
+	stats.ThreadPercent = models.CalculatePercentage(stats.Tasks, stats.ThreadsMax)
+
+	return &stats, nil
+}
@@ -0,0 +1,141 @@
+//go:build linux
+
+package stats
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// clockTicks is the kernel's USER_HZ, the unit of CPU times in
+// /proc/[pid]/stat. It is 100 on every mainstream Linux architecture.
+const clockTicks = 100
+
+// GetProcessStats walks /proc/[pid] and retrieves per-process statistics.
+// Processes that exit while being read are skipped.
+func (p *LinuxStatsProvider) GetProcessStats() ([]models.ProcessStats, error) {
+	entries, err := os.ReadDir(p.procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p.procRoot, err)
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	var stats []models.ProcessStats
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		dir := p.procPath(entry.Name())
+		proc, err := readProcessStat(dir, pageSize)
+		if err != nil {
+			continue // Process exited or is inaccessible
+		}
+		proc.PID = pid
+
+		if uid, err := readProcessUID(dir); err == nil {
+			proc.User = p.lookupUser(uid)
+		}
+
+		proc.Command = readProcessCmdline(dir)
+		if proc.Command == "" {
+			// Kernel threads have an empty cmdline
+			proc.Command = "[" + proc.Name + "]"
+		}
+
+		stats = append(stats, proc)
+	}
+
+	return stats, nil
+}
+
+// readProcessStat parses /proc/[pid]/stat
+func readProcessStat(dir string, pageSize uint64) (models.ProcessStats, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return models.ProcessStats{}, err
+	}
+
+	// The command name is wrapped in parentheses and may itself contain
+	// spaces or parentheses, so split on the last closing parenthesis.
+	line := string(data)
+	open := strings.IndexByte(line, '(')
+	end := strings.LastIndexByte(line, ')')
+	if open < 0 || end < open {
+		return models.ProcessStats{}, fmt.Errorf("malformed stat: %q", line)
+	}
+
+	// Fields after the command name, starting with state (field 3)
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 22 {
+		return models.ProcessStats{}, fmt.Errorf("short stat: %q", line)
+	}
+
+	utime := parseUint64(fields[11])
+	stime := parseUint64(fields[12])
+
+	return models.ProcessStats{
+		Name:    line[open+1 : end],
+		State:   fields[0],
+		PPID:    int(parseUint64(fields[1])),
+		Threads: parseUint64(fields[17]),
+		RSS:     parseUint64(fields[21]) * pageSize,
+		CPUTime: time.Duration(utime+stime) * time.Second / clockTicks,
+	}, nil
+}
+
+// readProcessUID returns the real UID from /proc/[pid]/status
+func readProcessUID(dir string) (string, error) {
+	file, err := os.Open(filepath.Join(dir, "status"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "Uid:" {
+			return fields[1], nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no Uid line in %s/status", dir)
+}
+
+// readProcessCmdline returns the NUL-separated /proc/[pid]/cmdline as a
+// space-separated string
+func readProcessCmdline(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+}
+
+// lookupUser resolves a UID to a user name, caching the result
+func (p *LinuxStatsProvider) lookupUser(uid string) string {
+	if name, ok := p.userNames[uid]; ok {
+		return name
+	}
+
+	name := uid
+	if u, err := user.LookupId(uid); err == nil {
+		name = u.Username
+	}
+	p.userNames[uid] = name
+	return name
+}
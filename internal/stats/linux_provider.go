@@ -4,6 +4,9 @@ package stats
 
 import "github.com/sysmon/system-monitor-cli/internal/collector"
 
-func newPlatformProvider() collector.SystemStatsProvider {
-	return NewLinuxStatsProvider()
+func newPlatformProvider(opts Options) collector.SystemStatsProvider {
+	provider := NewLinuxStatsProvider(opts.ProcRoot, opts.SysRoot)
+	provider.SetCgroupPath(opts.CgroupPath)
+	provider.SetHostRoot(opts.HostRoot)
+	return provider
 }
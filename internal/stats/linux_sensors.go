@@ -0,0 +1,148 @@
+//go:build linux
+
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// GetSensorStats retrieves temperature and fan readings from
+// /sys/class/hwmon and /sys/class/thermal. Machines without sensors (most
+// virtual machines) return empty readings rather than an error.
+func (p *LinuxStatsProvider) GetSensorStats() (*models.SensorStats, error) {
+	var stats models.SensorStats
+
+	chips, _ := filepath.Glob(p.sysPath("class", "hwmon", "hwmon*"))
+	sort.Strings(chips)
+
+	for _, dir := range chips {
+		// Identical chips, such as two NVMe drives, share a name but not
+		// their hwmon device
+		device := filepath.Base(dir)
+		chip := readSysString(filepath.Join(dir, "name"))
+		if chip == "" {
+			chip = device
+		}
+
+		inputs, _ := filepath.Glob(filepath.Join(dir, "temp*_input"))
+		sort.Strings(inputs)
+		for _, input := range inputs {
+			value, ok := readSysInt(input)
+			if !ok {
+				continue // Sensor not connected or read failed
+			}
+			prefix := strings.TrimSuffix(input, "_input")
+			high, _ := readSysInt(prefix + "_max")
+			crit, _ := readSysInt(prefix + "_crit")
+
+			stats.Temperatures = append(stats.Temperatures, models.TemperatureSensor{
+				Chip:     chip,
+				Device:   device,
+				Label:    sensorLabel(prefix),
+				Current:  milliToUnit(value),
+				High:     milliToUnit(high),
+				Critical: milliToUnit(crit),
+			})
+		}
+
+		fans, _ := filepath.Glob(filepath.Join(dir, "fan*_input"))
+		sort.Strings(fans)
+		for _, input := range fans {
+			rpm, ok := readSysInt(input)
+			if !ok {
+				continue
+			}
+			prefix := strings.TrimSuffix(input, "_input")
+			minRPM, _ := readSysInt(prefix + "_min")
+
+			stats.Fans = append(stats.Fans, models.FanSensor{
+				Chip:   chip,
+				Device: device,
+				Label:  sensorLabel(prefix),
+				RPM:    uint64(max(rpm, 0)),
+				Min:    uint64(max(minRPM, 0)),
+			})
+		}
+	}
+
+	zones, _ := filepath.Glob(p.sysPath("class", "thermal", "thermal_zone*"))
+	sort.Strings(zones)
+
+	for _, dir := range zones {
+		value, ok := readSysInt(filepath.Join(dir, "temp"))
+		if !ok {
+			continue
+		}
+		chip := readSysString(filepath.Join(dir, "type"))
+		if chip == "" {
+			chip = "thermal"
+		}
+
+		stats.Temperatures = append(stats.Temperatures, models.TemperatureSensor{
+			Chip:     chip,
+			Device:   filepath.Base(dir),
+			Label:    filepath.Base(dir),
+			Current:  milliToUnit(value),
+			Critical: milliToUnit(thermalZoneCritical(dir)),
+		})
+	}
+
+	return &stats, nil
+}
+
+// sensorLabel returns the contents of <prefix>_label, or the channel name
+// (e.g. "temp1") when the driver provides no label
+func sensorLabel(prefix string) string {
+	if label := readSysString(prefix + "_label"); label != "" {
+		return label
+	}
+	return filepath.Base(prefix)
+}
+
+// thermalZoneCritical returns the temperature of the zone's "critical"
+// trip point in millidegrees, or 0 if it has none
+func thermalZoneCritical(dir string) int64 {
+	types, _ := filepath.Glob(filepath.Join(dir, "trip_point_*_type"))
+	for _, typePath := range types {
+		if readSysString(typePath) != "critical" {
+			continue
+		}
+		temp, _ := readSysInt(strings.TrimSuffix(typePath, "_type") + "_temp")
+		return temp
+	}
+	return 0
+}
+
+// readSysString reads a single-value sysfs attribute
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysInt reads a single integer sysfs attribute. Some attributes, such
+// as temperatures, may be negative.
+func readSysInt(path string) (int64, bool) {
+	value := readSysString(path)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// milliToUnit converts a millidegree reading to degrees
+func milliToUnit(v int64) float64 {
+	return float64(v) / 1000.0
+}
@@ -0,0 +1,251 @@
+//go:build linux
+
+package stats
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+// Socket states from include/net/tcp_states.h, as hex in /proc/net/{tcp,udp}
+const (
+	tcpStateListen = 0x0A
+	udpStateUnconn = 0x07
+	tcpStateMax    = 0x0C
+)
+
+// GetSocketStats summarizes sockets from /proc/net/{tcp,tcp6,udp,udp6} and
+// /proc/net/sockstat. Missing IPv6 tables (IPv6 disabled) are skipped.
+func (p *LinuxStatsProvider) GetSocketStats() (*models.SocketStats, error) {
+	stats := models.SocketStats{
+		TCPStates: make(map[string]uint64),
+	}
+
+	portLow, portHigh := p.localPortRange()
+	if portHigh >= portLow && portLow > 0 {
+		stats.EphemeralPortsTotal = uint64(portHigh-portLow) + 1
+	}
+	ephemeral := make(map[uint16]bool)
+
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		entries, err := p.readSocketTable(proto)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		isTCP := strings.HasPrefix(proto, "tcp")
+		for _, entry := range entries {
+			if isTCP {
+				stats.TCPTotal++
+				stats.TCPStates[tcpStateName(entry.state)]++
+			} else {
+				stats.UDPTotal++
+			}
+
+			switch {
+			case isTCP && entry.state == tcpStateListen,
+				!isTCP && entry.state == udpStateUnconn && entry.remotePort == 0:
+				stats.Listening = append(stats.Listening, models.ListeningSocket{
+					Protocol: proto,
+					Address:  entry.localIP,
+					Port:     entry.localPort,
+				})
+			case isTCP && stats.EphemeralPortsTotal > 0 &&
+				entry.localPort >= portLow && entry.localPort <= portHigh:
+				ephemeral[entry.localPort] = true
+			}
+		}
+	}
+
+	stats.EphemeralPortsUsed = uint64(len(ephemeral))
+	stats.EphemeralPortsPercent = models.CalculatePercentage(stats.EphemeralPortsUsed, stats.EphemeralPortsTotal)
+
+	sort.Slice(stats.Listening, func(i, j int) bool {
+		a, b := stats.Listening[i], stats.Listening[j]
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		return a.Address < b.Address
+	})
+
+	if err := p.readSockstat(&stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// socketEntry is a parsed row of /proc/net/{tcp,udp}[6]
+type socketEntry struct {
+	localIP    string
+	localPort  uint16
+	remotePort uint16
+	state      uint64
+}
+
+// readSocketTable parses one of the /proc/net socket tables
+func (p *LinuxStatsProvider) readSocketTable(proto string) ([]socketEntry, error) {
+	path := p.procPath("net", proto)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var entries []socketEntry
+	scanner := bufio.NewScanner(file)
+
+	// Skip header line
+	scanner.Scan()
+
+	for scanner.Scan() {
+		// Format: sl local_address rem_address st ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		localIP, localPort, err := parseSocketAddress(fields[1])
+		if err != nil {
+			continue
+		}
+		_, remotePort, err := parseSocketAddress(fields[2])
+		if err != nil {
+			continue
+		}
+		state, err := strconv.ParseUint(fields[3], 16, 8)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, socketEntry{
+			localIP:    localIP,
+			localPort:  localPort,
+			remotePort: remotePort,
+			state:      state,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// parseSocketAddress decodes "0100007F:0035" style addresses. The IP is
+// stored as native-endian 32-bit words (little-endian on x86 and arm64)
+// and the port as big-endian hex.
+func parseSocketAddress(s string) (string, uint16, error) {
+	ipHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("malformed socket address: %q", s)
+	}
+
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed port: %q", s)
+	}
+
+	raw, err := hex.DecodeString(ipHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("malformed IP: %q", s)
+	}
+
+	// Reverse each 4-byte word
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+
+	return ip.String(), uint16(port), nil
+}
+
+// tcpStateName maps a kernel TCP state number to its name
+func tcpStateName(state uint64) string {
+	if state >= 1 && state <= tcpStateMax {
+		return models.TCPStateNames[state-1]
+	}
+	return fmt.Sprintf("UNKNOWN_%02X", state)
+}
+
+// readSockstat parses /proc/net/sockstat:
+//
+//	sockets: used 123
+//	TCP: inuse 5 orphan 0 tw 2 alloc 7 mem 1
+//	UDP: inuse 3 mem 2
+func (p *LinuxStatsProvider) readSockstat(stats *models.SocketStats) error {
+	path := p.procPath("net", "sockstat")
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	// Buffer memory is reported in pages
+	pageSize := uint64(os.Getpagesize())
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+
+		values := make(map[string]uint64)
+		for i := 1; i+1 < len(fields); i += 2 {
+			values[fields[i]] = parseUint64(fields[i+1])
+		}
+
+		switch fields[0] {
+		case "sockets:":
+			stats.SocketsUsed = values["used"]
+		case "TCP:":
+			stats.TCPInUse = values["inuse"]
+			stats.TCPOrphans = values["orphan"]
+			stats.TCPTimeWait = values["tw"]
+			stats.TCPAlloc = values["alloc"]
+			stats.TCPMemory = values["mem"] * pageSize
+		case "UDP:":
+			stats.UDPInUse = values["inuse"]
+			stats.UDPMemory = values["mem"] * pageSize
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// localPortRange returns the ephemeral port range from
+// /proc/sys/net/ipv4/ip_local_port_range, or 0, 0 if unavailable
+func (p *LinuxStatsProvider) localPortRange() (uint16, uint16) {
+	data, err := os.ReadFile(p.procPath("sys", "net", "ipv4", "ip_local_port_range"))
+	if err != nil {
+		return 0, 0
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	return uint16(parseUint64(fields[0])), uint16(parseUint64(fields[1]))
+}
@@ -0,0 +1,662 @@
+//go:build linux
+
+package stats
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
+
+const (
+	fixtureProcRoot     = "testdata/proc"
+	fixtureProcNextRoot = "testdata/proc-next"
+	fixtureSysRoot      = "testdata/sys"
+)
+
+func newFixtureProvider() *LinuxStatsProvider {
+	return NewLinuxStatsProvider(fixtureProcRoot, fixtureSysRoot)
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+func TestNewLinuxStatsProviderDefaults(t *testing.T) {
+	p := NewLinuxStatsProvider("", "")
+	if p.procRoot != DefaultProcRoot {
+		t.Errorf("procRoot = %q, want %q", p.procRoot, DefaultProcRoot)
+	}
+	if p.sysRoot != DefaultSysRoot {
+		t.Errorf("sysRoot = %q, want %q", p.sysRoot, DefaultSysRoot)
+	}
+	if p.hostRoot != DefaultHostRoot {
+		t.Errorf("hostRoot = %q, want %q", p.hostRoot, DefaultHostRoot)
+	}
+}
+
+func TestGetHostStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetHostStats()
+	if err != nil {
+		t.Fatalf("GetHostStats() error = %v", err)
+	}
+
+	if stats.Load1 != 1.25 || stats.Load5 != 0.98 || stats.Load15 != 0.75 {
+		t.Errorf("load = %v %v %v, want 1.25 0.98 0.75", stats.Load1, stats.Load5, stats.Load15)
+	}
+	if stats.RunningTasks != 3 || stats.TotalTasks != 412 {
+		t.Errorf("tasks = %d/%d, want 3/412", stats.RunningTasks, stats.TotalTasks)
+	}
+	if want := 266400500 * time.Millisecond; stats.Uptime != want {
+		t.Errorf("Uptime = %v, want %v", stats.Uptime, want)
+	}
+}
+
+func TestGetCPUStats(t *testing.T) {
+	p := newFixtureProvider()
+
+	first, err := p.GetCPUStats()
+	if err != nil {
+		t.Fatalf("GetCPUStats() error = %v", err)
+	}
+	if first.Overall != 0 || len(first.PerCore) != 2 {
+		t.Fatalf("first sample = %+v, want zero overall and 2 cores", first)
+	}
+
+	p.procRoot = fixtureProcNextRoot
+	second, err := p.GetCPUStats()
+	if err != nil {
+		t.Fatalf("GetCPUStats() error = %v", err)
+	}
+
+	// Deltas: user 600, system 200, idle 800, iowait 200, steal 200
+	if !almostEqual(second.Overall, 50.0) {
+		t.Errorf("Overall = %.2f, want 50.00", second.Overall)
+	}
+
+	b := second.Breakdown
+	if !almostEqual(b.User, 30.0) || !almostEqual(b.System, 10.0) || !almostEqual(b.Idle, 40.0) ||
+		!almostEqual(b.IOWait, 10.0) || !almostEqual(b.Steal, 10.0) {
+		t.Errorf("Breakdown = %+v, want user 30 system 10 idle 40 iowait 10 steal 10", b)
+	}
+
+	if len(second.PerCoreBreakdown) != 2 {
+		t.Fatalf("len(PerCoreBreakdown) = %d, want 2", len(second.PerCoreBreakdown))
+	}
+}
+
+func TestCoreFrequencies(t *testing.T) {
+	stats, err := newFixtureProvider().GetCPUStats()
+	if err != nil {
+		t.Fatalf("GetCPUStats() error = %v", err)
+	}
+
+	want := []models.CoreFrequency{
+		{CurrentMHz: 2400, MinMHz: 800, MaxMHz: 3600, ThrottleCount: 12, PackageThrottleCount: 40},
+		{CurrentMHz: 3100, MinMHz: 800, MaxMHz: 3600, ThrottleCount: 0, PackageThrottleCount: 40},
+	}
+	if len(stats.PerCoreFrequency) != len(want) {
+		t.Fatalf("PerCoreFrequency = %+v, want %d cores", stats.PerCoreFrequency, len(want))
+	}
+	for i := range want {
+		if stats.PerCoreFrequency[i] != want[i] {
+			t.Errorf("PerCoreFrequency[%d] = %+v, want %+v", i, stats.PerCoreFrequency[i], want[i])
+		}
+	}
+}
+
+func TestCoreThrottleRate(t *testing.T) {
+	sysRoot := t.TempDir()
+	dir := filepath.Join(sysRoot, "devices", "system", "cpu", "cpu0", "thermal_throttle")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeCount := func(n string) {
+		if err := os.WriteFile(filepath.Join(dir, "core_throttle_count"), []byte(n+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := NewLinuxStatsProvider(fixtureProcRoot, sysRoot)
+	cores := []cpuTime{{name: "cpu0"}}
+
+	writeCount("10")
+	p.readCoreFrequencies(cores)
+
+	// Pretend the previous sample was taken two seconds ago
+	p.prevThrottleTime = p.prevThrottleTime.Add(-2 * time.Second)
+	writeCount("20")
+	freq := p.readCoreFrequencies(cores)
+
+	if freq[0].CurrentMHz != 0 {
+		t.Errorf("CurrentMHz = %v, want 0 without cpufreq", freq[0].CurrentMHz)
+	}
+	if freq[0].ThrottleRate < 4.9 || freq[0].ThrottleRate > 5.0 {
+		t.Errorf("ThrottleRate = %.2f, want ~5/s", freq[0].ThrottleRate)
+	}
+}
+
+func TestGetMemoryStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetMemoryStats()
+	if err != nil {
+		t.Fatalf("GetMemoryStats() error = %v", err)
+	}
+
+	const kb = 1024
+	checks := []struct {
+		name      string
+		got, want uint64
+	}{
+		{"Total", stats.Total, 16384000 * kb},
+		{"Available", stats.Available, 8192000 * kb},
+		{"Used", stats.Used, 8192000 * kb},
+		{"Buffers", stats.Buffers, 512000 * kb},
+		{"Cached", stats.Cached, 4096000 * kb},
+		{"Dirty", stats.Dirty, 1024 * kb},
+		{"Shmem", stats.Shmem, 256000 * kb},
+		{"SlabReclaimable", stats.SlabReclaimable, 400000 * kb},
+		{"SlabUnreclaimable", stats.SlabUnreclaimable, 200000 * kb},
+		{"HugePagesTotal", stats.HugePagesTotal, 16},
+		{"HugePagesFree", stats.HugePagesFree, 4},
+		{"HugePageSize", stats.HugePageSize, 2048 * kb},
+		{"Swap.Total", stats.Swap.Total, 4096000 * kb},
+		{"Swap.Used", stats.Swap.Used, 1024000 * kb},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+
+	if !almostEqual(stats.Percent, 50.0) {
+		t.Errorf("Percent = %.2f, want 50.00", stats.Percent)
+	}
+	if !almostEqual(stats.Swap.Percent, 25.0) {
+		t.Errorf("Swap.Percent = %.2f, want 25.00", stats.Swap.Percent)
+	}
+}
+
+func TestGetDiskStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetDiskStats()
+	if err != nil {
+		t.Fatalf("GetDiskStats() error = %v", err)
+	}
+
+	// Pseudo filesystems, duplicates and unstattable mountpoints are skipped
+	if len(stats) != 1 || stats[0].Mountpoint != "/" {
+		t.Fatalf("GetDiskStats() = %+v, want only /", stats)
+	}
+	if stats[0].Total == 0 {
+		t.Error("Total = 0, want size of /")
+	}
+}
+
+func TestGetDiskStatsHostRoot(t *testing.T) {
+	p := newFixtureProvider()
+	p.SetHostRoot("testdata/host")
+
+	stats, err := p.GetDiskStats()
+	if err != nil {
+		t.Fatalf("GetDiskStats() error = %v", err)
+	}
+
+	// Mountpoints come from PID 1's mount table and are measured below the
+	// host root, but keep their host paths
+	var mountpoints []string
+	for _, d := range stats {
+		mountpoints = append(mountpoints, d.Mountpoint)
+	}
+	if len(stats) != 2 || stats[0].Mountpoint != "/" || stats[1].Mountpoint != "/sysmon-fixture-host" {
+		t.Fatalf("GetDiskStats() mountpoints = %v, want [/ /sysmon-fixture-host]", mountpoints)
+	}
+}
+
+func TestGetDiskStatsWithoutPID1(t *testing.T) {
+	// Without PID 1's mount table, /proc/mounts is read instead
+	procRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(procRoot, "mounts"), []byte("/dev/root / ext4 rw 0 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := NewLinuxStatsProvider(procRoot, fixtureSysRoot).GetDiskStats()
+	if err != nil {
+		t.Fatalf("GetDiskStats() error = %v", err)
+	}
+	if len(stats) != 1 || stats[0].Mountpoint != "/" {
+		t.Fatalf("GetDiskStats() = %+v, want only /", stats)
+	}
+}
+
+func TestGetDiskIOStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetDiskIOStats()
+	if err != nil {
+		t.Fatalf("GetDiskIOStats() error = %v", err)
+	}
+
+	if len(stats) != 3 {
+		t.Fatalf("len(stats) = %d, want 3 (loop devices skipped)", len(stats))
+	}
+
+	sda := stats[0]
+	if sda.Device != "sda" {
+		t.Fatalf("Device = %q, want sda", sda.Device)
+	}
+	if sda.ReadsCompleted != 1000 || sda.WritesCompleted != 2000 {
+		t.Errorf("completed = %d/%d, want 1000/2000", sda.ReadsCompleted, sda.WritesCompleted)
+	}
+	if sda.ReadBytes != 204800*512 || sda.WriteBytes != 409600*512 {
+		t.Errorf("bytes = %d/%d, want %d/%d", sda.ReadBytes, sda.WriteBytes, 204800*512, 409600*512)
+	}
+	if sda.ReadTimeMs != 1500 || sda.WriteTimeMs != 3500 || sda.IOTimeMs != 4000 {
+		t.Errorf("times = %d/%d/%d, want 1500/3500/4000", sda.ReadTimeMs, sda.WriteTimeMs, sda.IOTimeMs)
+	}
+}
+
+func TestGetNetworkStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetNetworkStats()
+	if err != nil {
+		t.Fatalf("GetNetworkStats() error = %v", err)
+	}
+
+	if len(stats) != 1 {
+		t.Fatalf("len(stats) = %d, want 1 (loopback skipped)", len(stats))
+	}
+
+	eth0 := stats[0]
+	checks := []struct {
+		name      string
+		got, want uint64
+	}{
+		{"BytesRecv", eth0.BytesRecv, 3435973836},
+		{"PacketsRecv", eth0.PacketsRecv, 2500000},
+		{"ErrorsRecv", eth0.ErrorsRecv, 12},
+		{"DropsRecv", eth0.DropsRecv, 34},
+		{"FifoRecv", eth0.FifoRecv, 1},
+		{"FrameRecv", eth0.FrameRecv, 2},
+		{"MulticastRecv", eth0.MulticastRecv, 150},
+		{"BytesSent", eth0.BytesSent, 1610612736},
+		{"PacketsSent", eth0.PacketsSent, 1200000},
+		{"ErrorsSent", eth0.ErrorsSent, 5},
+		{"DropsSent", eth0.DropsSent, 6},
+		{"Collisions", eth0.Collisions, 7},
+		{"CarrierSent", eth0.CarrierSent, 8},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestGetProcessStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetProcessStats()
+	if err != nil {
+		t.Fatalf("GetProcessStats() error = %v", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d, want 2", len(stats))
+	}
+
+	pageSize := uint64(os.Getpagesize())
+
+	initProc := stats[0]
+	if initProc.PID != 1 || initProc.Name != "systemd" || initProc.Command != "/sbin/init splash" {
+		t.Errorf("pid 1 = %+v", initProc)
+	}
+	if initProc.CPUTime != 20*time.Second {
+		t.Errorf("pid 1 CPUTime = %v, want 20s", initProc.CPUTime)
+	}
+	if initProc.RSS != 3000*pageSize {
+		t.Errorf("pid 1 RSS = %d, want %d", initProc.RSS, 3000*pageSize)
+	}
+	if initProc.User != "root" {
+		t.Errorf("pid 1 User = %q, want root", initProc.User)
+	}
+
+	// Names containing spaces and parentheses, and an empty cmdline
+	worker := stats[1]
+	if worker.Name != "my (odd) worker" {
+		t.Errorf("Name = %q, want %q", worker.Name, "my (odd) worker")
+	}
+	if worker.Command != "[my (odd) worker]" {
+		t.Errorf("Command = %q, want %q", worker.Command, "[my (odd) worker]")
+	}
+	if worker.State != "R" || worker.PPID != 1 || worker.Threads != 8 {
+		t.Errorf("state/ppid/threads = %s/%d/%d, want R/1/8", worker.State, worker.PPID, worker.Threads)
+	}
+	if worker.CPUTime != 35*time.Second {
+		t.Errorf("CPUTime = %v, want 35s", worker.CPUTime)
+	}
+}
+
+func TestGetPressureStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetPressureStats()
+	if err != nil {
+		t.Fatalf("GetPressureStats() error = %v", err)
+	}
+
+	if !stats.Available {
+		t.Fatal("Available = false, want true")
+	}
+	if stats.CPU.Some.Avg10 != 12.5 || stats.CPU.Some.Avg60 != 8.25 || stats.CPU.Some.Avg300 != 3.0 {
+		t.Errorf("CPU.Some = %+v", stats.CPU.Some)
+	}
+	if stats.CPU.Some.Total != 19292443*time.Microsecond {
+		t.Errorf("CPU.Some.Total = %v, want %v", stats.CPU.Some.Total, 19292443*time.Microsecond)
+	}
+	// Older kernels have no "full" line for cpu
+	if stats.CPU.Full != (models.PressureLine{}) {
+		t.Errorf("CPU.Full = %+v, want zero", stats.CPU.Full)
+	}
+	if stats.Memory.Full.Avg10 != 0.2 || stats.IO.Full.Avg60 != 2.5 {
+		t.Errorf("Memory.Full = %+v, IO.Full = %+v", stats.Memory.Full, stats.IO.Full)
+	}
+}
+
+func TestGetPressureStatsUnsupported(t *testing.T) {
+	// The proc-next fixture has no pressure directory
+	p := NewLinuxStatsProvider(fixtureProcNextRoot, fixtureSysRoot)
+
+	stats, err := p.GetPressureStats()
+	if err != nil {
+		t.Fatalf("GetPressureStats() error = %v, want nil", err)
+	}
+	if stats.Available {
+		t.Error("Available = true, want false")
+	}
+}
+
+func TestGetCgroupStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetCgroupStats()
+	if err != nil {
+		t.Fatalf("GetCgroupStats() error = %v", err)
+	}
+
+	if !stats.Available || stats.Path != "/system.slice/app.service" {
+		t.Fatalf("Available/Path = %v/%q, want true//system.slice/app.service", stats.Available, stats.Path)
+	}
+	if stats.CPUUsage != 300*time.Second || stats.ThrottledTime != 3*time.Second {
+		t.Errorf("CPUUsage/ThrottledTime = %v/%v, want 5m0s/3s", stats.CPUUsage, stats.ThrottledTime)
+	}
+	if stats.NrPeriods != 1000 || stats.NrThrottled != 25 {
+		t.Errorf("periods = %d/%d, want 25/1000", stats.NrThrottled, stats.NrPeriods)
+	}
+	if stats.CPULimit != 2.0 {
+		t.Errorf("CPULimit = %v, want 2", stats.CPULimit)
+	}
+	if stats.MemoryCurrent != 768<<20 || stats.MemoryMax != 1<<30 || !almostEqual(stats.MemoryPercent, 75.0) {
+		t.Errorf("memory = %d/%d (%.2f%%), want 768MiB/1GiB (75%%)",
+			stats.MemoryCurrent, stats.MemoryMax, stats.MemoryPercent)
+	}
+	if stats.MemoryEvents.OOMKill != 1 || stats.MemoryEvents.High != 4 {
+		t.Errorf("MemoryEvents = %+v", stats.MemoryEvents)
+	}
+	want := []models.CgroupIOStats{{Device: "8:0", ReadBytes: 1 << 20, WriteBytes: 2 << 20, ReadIOs: 10, WriteIOs: 20}}
+	if len(stats.IO) != 1 || stats.IO[0] != want[0] {
+		t.Errorf("IO = %+v, want %+v", stats.IO, want)
+	}
+}
+
+func TestGetCgroupStatsConfiguredPath(t *testing.T) {
+	p := newFixtureProvider()
+	p.SetCgroupPath("/does/not/exist")
+
+	if _, err := p.GetCgroupStats(); err == nil {
+		t.Error("GetCgroupStats() error = nil, want error for missing cgroup")
+	}
+}
+
+func TestGetCgroupStatsUnavailable(t *testing.T) {
+	p := NewLinuxStatsProvider(fixtureProcRoot, "testdata/does-not-exist")
+
+	stats, err := p.GetCgroupStats()
+	if err != nil {
+		t.Fatalf("GetCgroupStats() error = %v, want nil", err)
+	}
+	if stats.Available {
+		t.Error("Available = true, want false")
+	}
+}
+
+func TestGetSensorStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetSensorStats()
+	if err != nil {
+		t.Fatalf("GetSensorStats() error = %v", err)
+	}
+
+	wantTemps := []models.TemperatureSensor{
+		{Chip: "coretemp", Device: "hwmon0", Label: "Package id 0", Current: 54.0, High: 80.0, Critical: 100.0},
+		{Chip: "coretemp", Device: "hwmon0", Label: "Core 0", Current: 51.0, Critical: 100.0},
+		{Chip: "nct6775", Device: "hwmon1", Label: "temp1", Current: -5.0},
+		{Chip: "acpitz", Device: "thermal_zone0", Label: "thermal_zone0", Current: 27.8, Critical: 119.0},
+	}
+	if len(stats.Temperatures) != len(wantTemps) {
+		t.Fatalf("Temperatures = %+v, want %d sensors", stats.Temperatures, len(wantTemps))
+	}
+	for i, want := range wantTemps {
+		if got := stats.Temperatures[i]; got != want {
+			t.Errorf("Temperatures[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	wantFans := []models.FanSensor{
+		{Chip: "nct6775", Device: "hwmon1", Label: "CPU Fan", RPM: 1250, Min: 300},
+		{Chip: "nct6775", Device: "hwmon1", Label: "fan2", RPM: 0, Min: 600},
+	}
+	if len(stats.Fans) != len(wantFans) {
+		t.Fatalf("Fans = %+v, want %d fans", stats.Fans, len(wantFans))
+	}
+	for i, want := range wantFans {
+		if got := stats.Fans[i]; got != want {
+			t.Errorf("Fans[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestGetSensorStatsNoSensors(t *testing.T) {
+	p := NewLinuxStatsProvider(fixtureProcRoot, "testdata/does-not-exist")
+
+	stats, err := p.GetSensorStats()
+	if err != nil {
+		t.Fatalf("GetSensorStats() error = %v, want nil", err)
+	}
+	if len(stats.Temperatures) != 0 || len(stats.Fans) != 0 {
+		t.Errorf("GetSensorStats() = %+v, want no readings", stats)
+	}
+}
+
+func TestGetActivityStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetActivityStats()
+	if err != nil {
+		t.Fatalf("GetActivityStats() error = %v", err)
+	}
+
+	want := models.ActivityStats{
+		ContextSwitches: 987654,
+		Interrupts:      123456,
+		Forks:           54321,
+		ProcsRunning:    3,
+		ProcsBlocked:    1,
+		PageFaults:      4500000,
+		MajorPageFaults: 1200,
+		PagedIn:         2048000,
+		PagedOut:        1024000,
+		SwappedIn:       10,
+		SwappedOut:      20,
+		OOMKills:        2,
+	}
+	if *stats != want {
+		t.Errorf("GetActivityStats() = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestGetActivityStatsWithoutVmstat(t *testing.T) {
+	p := newFixtureProvider()
+	p.procRoot = fixtureProcNextRoot
+
+	stats, err := p.GetActivityStats()
+	if err != nil {
+		t.Fatalf("GetActivityStats() error = %v", err)
+	}
+	if stats.ContextSwitches != 997654 || stats.ProcsRunning != 5 {
+		t.Errorf("ContextSwitches/ProcsRunning = %d/%d, want 997654/5", stats.ContextSwitches, stats.ProcsRunning)
+	}
+	if stats.PageFaults != 0 || stats.OOMKills != 0 {
+		t.Errorf("vmstat counters = %+v, want zero without /proc/vmstat", stats)
+	}
+}
+
+func TestGetActivityStatsReusesCPUPass(t *testing.T) {
+	p := newFixtureProvider()
+	if _, err := p.GetCPUStats(); err != nil {
+		t.Fatalf("GetCPUStats() error = %v", err)
+	}
+
+	// The /proc/stat counters come from the CPU pass, not the file
+	p.procRoot = "testdata/does-not-exist"
+	stats, err := p.GetActivityStats()
+	if err != nil {
+		t.Fatalf("GetActivityStats() error = %v", err)
+	}
+	if stats.ContextSwitches != 987654 || stats.ProcsBlocked != 1 {
+		t.Errorf("ContextSwitches/ProcsBlocked = %d/%d, want 987654/1", stats.ContextSwitches, stats.ProcsBlocked)
+	}
+
+	// The counters are used once; without a new CPU pass the file is read
+	if _, err := p.GetActivityStats(); err == nil {
+		t.Error("second GetActivityStats() error = nil, want error")
+	}
+}
+
+func TestGetKernelTableStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetKernelTableStats()
+	if err != nil {
+		t.Fatalf("GetKernelTableStats() error = %v", err)
+	}
+
+	if stats.FilesAllocated != 12800 || stats.FilesMax != 1048576 {
+		t.Errorf("files = %d/%d, want 12800/1048576", stats.FilesAllocated, stats.FilesMax)
+	}
+	if !almostEqual(stats.FilesPercent, 1.22) {
+		t.Errorf("FilesPercent = %.2f, want 1.22", stats.FilesPercent)
+	}
+	if stats.InodesAllocated != 250000 || stats.InodesFree != 1200 {
+		t.Errorf("inodes = %d/%d, want 250000/1200", stats.InodesAllocated, stats.InodesFree)
+	}
+
+	// 412 tasks from loadavg
+	if stats.Tasks != 412 || stats.PIDMax != 4194304 || stats.ThreadsMax != 126000 {
+		t.Errorf("tasks = %d pid_max %d threads-max %d, want 412/4194304/126000",
+			stats.Tasks, stats.PIDMax, stats.ThreadsMax)
+	}
+	if !almostEqual(stats.ThreadPercent, 0.33) || !almostEqual(stats.PIDPercent, 0.01) {
+		t.Errorf("ThreadPercent/PIDPercent = %.2f/%.2f, want 0.33/0.01", stats.ThreadPercent, stats.PIDPercent)
+	}
+}
+
+func TestGetSocketStats(t *testing.T) {
+	stats, err := newFixtureProvider().GetSocketStats()
+	if err != nil {
+		t.Fatalf("GetSocketStats() error = %v", err)
+	}
+
+	// udp6 is missing from the fixture, as on hosts with IPv6 disabled
+	if stats.TCPTotal != 7 || stats.UDPTotal != 2 {
+		t.Errorf("TCPTotal/UDPTotal = %d/%d, want 7/2", stats.TCPTotal, stats.UDPTotal)
+	}
+
+	wantStates := map[string]uint64{"LISTEN": 3, "ESTABLISHED": 2, "TIME_WAIT": 1, "CLOSE_WAIT": 1}
+	if len(stats.TCPStates) != len(wantStates) {
+		t.Errorf("TCPStates = %v, want %v", stats.TCPStates, wantStates)
+	}
+	for state, want := range wantStates {
+		if got := stats.TCPStates[state]; got != want {
+			t.Errorf("TCPStates[%s] = %d, want %d", state, got, want)
+		}
+	}
+
+	wantListening := []models.ListeningSocket{
+		{Protocol: "tcp", Address: "0.0.0.0", Port: 22},
+		{Protocol: "udp", Address: "127.0.0.53", Port: 53},
+		{Protocol: "tcp6", Address: "::", Port: 443},
+		{Protocol: "tcp", Address: "127.0.0.1", Port: 3306},
+	}
+	if len(stats.Listening) != len(wantListening) {
+		t.Fatalf("Listening = %+v, want %+v", stats.Listening, wantListening)
+	}
+	for i, want := range wantListening {
+		if stats.Listening[i] != want {
+			t.Errorf("Listening[%d] = %+v, want %+v", i, stats.Listening[i], want)
+		}
+	}
+
+	// Local ports 36000, 36001 and 36002 fall within 32768-60999
+	if stats.EphemeralPortsUsed != 3 || stats.EphemeralPortsTotal != 28232 {
+		t.Errorf("ephemeral ports = %d/%d, want 3/28232", stats.EphemeralPortsUsed, stats.EphemeralPortsTotal)
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	if stats.SocketsUsed != 410 || stats.TCPOrphans != 2 || stats.TCPTimeWait != 130 {
+		t.Errorf("sockstat = used %d orphan %d tw %d, want 410/2/130",
+			stats.SocketsUsed, stats.TCPOrphans, stats.TCPTimeWait)
+	}
+	if stats.TCPMemory != 24*pageSize || stats.UDPMemory != 5*pageSize {
+		t.Errorf("memory = %d/%d, want %d/%d", stats.TCPMemory, stats.UDPMemory, 24*pageSize, 5*pageSize)
+	}
+}
+
+func TestParseSocketAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		ip    string
+		port  uint16
+	}{
+		{"0100007F:0035", "127.0.0.1", 53},
+		{"00000000:0016", "0.0.0.0", 22},
+		{"00000000000000000000000001000000:1F90", "::1", 8080},
+		{"0000000000000000FFFF00000F02000A:01BB", "10.0.2.15", 443},
+	}
+
+	for _, tt := range tests {
+		ip, port, err := parseSocketAddress(tt.input)
+		if err != nil {
+			t.Errorf("parseSocketAddress(%q) error = %v", tt.input, err)
+			continue
+		}
+		if ip != tt.ip || port != tt.port {
+			t.Errorf("parseSocketAddress(%q) = %s:%d, want %s:%d", tt.input, ip, port, tt.ip, tt.port)
+		}
+	}
+
+	if _, _, err := parseSocketAddress("garbage"); err == nil {
+		t.Error("parseSocketAddress(garbage) error = nil, want error")
+	}
+}
+
+func TestMissingProcRoot(t *testing.T) {
+	p := NewLinuxStatsProvider("testdata/does-not-exist", fixtureSysRoot)
+
+	if _, err := p.GetHostStats(); err == nil {
+		t.Error("GetHostStats() error = nil, want error")
+	}
+	if _, err := p.GetCPUStats(); err == nil {
+		t.Error("GetCPUStats() error = nil, want error")
+	}
+	if _, err := p.GetMemoryStats(); err == nil {
+		t.Error("GetMemoryStats() error = nil, want error")
+	}
+	if _, err := p.GetNetworkStats(); err == nil {
+		t.Error("GetNetworkStats() error = nil, want error")
+	}
+	if _, err := p.GetActivityStats(); err == nil {
+		t.Error("GetActivityStats() error = nil, want error")
+	}
+	if _, err := p.GetKernelTableStats(); err == nil {
+		t.Error("GetKernelTableStats() error = nil, want error")
+	}
+}
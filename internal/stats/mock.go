@@ -1,32 +1,83 @@
 package stats
 
-import "github.com/sysmon/system-monitor-cli/internal/models"
+import (
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+)
 
 // MockStatsProvider provides controllable test data for testing
 type MockStatsProvider struct {
-	CPUStats  *models.CPUStats
-	MemStats  *models.MemoryStats
-	DiskStats []models.DiskStats
-	NetStats  []models.NetworkStats
-
-	CPUError  error
-	MemError  error
-	DiskError error
-	NetError  error
+	HostStats   *models.HostStats
+	CPUStats    *models.CPUStats
+	MemStats    *models.MemoryStats
+	DiskStats   []models.DiskStats
+	DiskIOStats []models.DiskIOStats
+	NetStats    []models.NetworkStats
+	ProcStats   []models.ProcessStats
+	Pressure    *models.PressureStats
+	Cgroup      *models.CgroupStats
+	Sensors     *models.SensorStats
+	Sockets     *models.SocketStats
+	Activity    *models.ActivityStats
+	Kernel      *models.KernelTableStats
+
+	HostError     error
+	CPUError      error
+	MemError      error
+	DiskError     error
+	DiskIOError   error
+	NetError      error
+	ProcError     error
+	PSIError      error
+	CgroupError   error
+	SensorError   error
+	SocketError   error
+	ActivityError error
+	KernelError   error
 }
 
 // NewMockStatsProvider creates a new mock stats provider with default values
 func NewMockStatsProvider() *MockStatsProvider {
 	return &MockStatsProvider{
+		HostStats: &models.HostStats{
+			Load1:        1.25,
+			Load5:        0.98,
+			Load15:       0.75,
+			Uptime:       72 * time.Hour,
+			RunningTasks: 2,
+			TotalTasks:   312,
+		},
 		CPUStats: &models.CPUStats{
 			Overall: 25.5,
 			PerCore: []float64{20.0, 30.0, 25.0, 28.0},
+			PerCoreFrequency: []models.CoreFrequency{
+				{CurrentMHz: 2400, MinMHz: 800, MaxMHz: 3600},
+				{CurrentMHz: 3100, MinMHz: 800, MaxMHz: 3600},
+				{CurrentMHz: 1200, MinMHz: 800, MaxMHz: 3600},
+				{CurrentMHz: 2800, MinMHz: 800, MaxMHz: 3600},
+			},
+			Breakdown: models.CPUBreakdown{
+				User:   15.0,
+				System: 7.5,
+				Idle:   74.5,
+				IOWait: 2.0,
+				Steal:  1.0,
+			},
 		},
 		MemStats: &models.MemoryStats{
 			Total:     16 * 1024 * 1024 * 1024, // 16 GB
 			Used:      8 * 1024 * 1024 * 1024,  // 8 GB
 			Available: 8 * 1024 * 1024 * 1024,  // 8 GB
 			Percent:   50.0,
+			Cached:    4 * 1024 * 1024 * 1024, // 4 GB
+			Buffers:   512 * 1024 * 1024,      // 512 MB
+			Swap: models.SwapStats{
+				Total:   4 * 1024 * 1024 * 1024, // 4 GB
+				Used:    1 * 1024 * 1024 * 1024, // 1 GB
+				Free:    3 * 1024 * 1024 * 1024, // 3 GB
+				Percent: 25.0,
+			},
 		},
 		DiskStats: []models.DiskStats{
 			{
@@ -35,6 +86,26 @@ func NewMockStatsProvider() *MockStatsProvider {
 				Used:       300 * 1024 * 1024 * 1024, // 300 GB
 				Available:  200 * 1024 * 1024 * 1024, // 200 GB
 				Percent:    60.0,
+
+				InodesTotal:   32 * 1024 * 1024,
+				InodesUsed:    4 * 1024 * 1024,
+				InodesFree:    28 * 1024 * 1024,
+				InodesPercent: 12.5,
+			},
+		},
+		DiskIOStats: []models.DiskIOStats{
+			{
+				Device:          "sda",
+				ReadsCompleted:  120000,
+				WritesCompleted: 80000,
+				ReadBytes:       4 * 1024 * 1024 * 1024, // 4 GB
+				WriteBytes:      2 * 1024 * 1024 * 1024, // 2 GB
+				ReadRate:        1024 * 512,             // 512 KB/s
+				WriteRate:       1024 * 256,             // 256 KB/s
+				ReadIOPS:        40,
+				WriteIOPS:       25,
+				AwaitMs:         1.8,
+				Util:            12.5,
 			},
 		},
 		NetStats: []models.NetworkStats{
@@ -44,11 +115,137 @@ func NewMockStatsProvider() *MockStatsProvider {
 				BytesRecv: 1024 * 1024 * 200, // 200 MB
 				SendRate:  1024 * 100,        // 100 KB/s
 				RecvRate:  1024 * 200,        // 200 KB/s
+
+				PacketsSent:    75000,
+				PacketsRecv:    150000,
+				ErrorsRecv:     3,
+				DropsRecv:      12,
+				PacketSendRate: 80,
+				PacketRecvRate: 160,
+			},
+		},
+		Pressure: &models.PressureStats{
+			Available: true,
+			CPU: models.PressureResource{
+				Some: models.PressureLine{Avg10: 4.2, Avg60: 3.1, Avg300: 2.5, Total: 90 * time.Second},
+			},
+			Memory: models.PressureResource{
+				Some: models.PressureLine{Avg10: 0.5, Avg60: 0.2, Avg300: 0.1, Total: 3 * time.Second},
+				Full: models.PressureLine{Avg10: 0.1, Total: time.Second},
+			},
+			IO: models.PressureResource{
+				Some: models.PressureLine{Avg10: 1.5, Avg60: 1.0, Avg300: 0.8, Total: 20 * time.Second},
+				Full: models.PressureLine{Avg10: 0.9, Avg60: 0.6, Avg300: 0.4, Total: 12 * time.Second},
+			},
+		},
+		Cgroup: &models.CgroupStats{
+			Available:     true,
+			Path:          "/system.slice/app.service",
+			CPUUsage:      5 * time.Minute,
+			NrPeriods:     1000,
+			NrThrottled:   25,
+			ThrottledTime: 3 * time.Second,
+			CPULimit:      2.0,
+			CPUPercent:    42.0,
+			MemoryCurrent: 768 * 1024 * 1024,  // 768 MB
+			MemoryMax:     1024 * 1024 * 1024, // 1 GB
+			MemoryPercent: 75.0,
+		},
+		Sensors: &models.SensorStats{
+			Temperatures: []models.TemperatureSensor{
+				{Chip: "coretemp", Device: "hwmon1", Label: "Package id 0", Current: 54.0, High: 80.0, Critical: 100.0},
+				{Chip: "coretemp", Device: "hwmon1", Label: "Core 0", Current: 51.0, High: 80.0, Critical: 100.0},
+				{Chip: "acpitz", Device: "thermal_zone0", Label: "thermal_zone0", Current: 27.8, Critical: 119.0},
+			},
+			Fans: []models.FanSensor{
+				{Chip: "nct6775", Device: "hwmon2", Label: "CPU Fan", RPM: 1250, Min: 300},
+			},
+		},
+		Sockets: &models.SocketStats{
+			TCPStates: map[string]uint64{
+				"ESTABLISHED": 42,
+				"TIME_WAIT":   130,
+				"LISTEN":      3,
+			},
+			TCPTotal: 175,
+			UDPTotal: 2,
+			Listening: []models.ListeningSocket{
+				{Protocol: "tcp", Address: "0.0.0.0", Port: 22},
+				{Protocol: "tcp6", Address: "::", Port: 443},
+				{Protocol: "udp", Address: "127.0.0.53", Port: 53},
+			},
+			SocketsUsed:           410,
+			TCPInUse:              45,
+			TCPTimeWait:           130,
+			TCPAlloc:              50,
+			TCPMemory:             24 * 4096,
+			EphemeralPortsUsed:    40,
+			EphemeralPortsTotal:   28232,
+			EphemeralPortsPercent: 0.14,
+		},
+		Activity: &models.ActivityStats{
+			ContextSwitches: 987654,
+			Interrupts:      123456,
+			Forks:           54321,
+			ProcsRunning:    3,
+			ProcsBlocked:    1,
+			PageFaults:      4500000,
+			MajorPageFaults: 1200,
+			PagedIn:         2048000,
+			PagedOut:        1024000,
+			SwappedIn:       10,
+			SwappedOut:      20,
+		},
+		Kernel: &models.KernelTableStats{
+			FilesAllocated:  12800,
+			FilesMax:        1048576,
+			FilesPercent:    1.22,
+			InodesAllocated: 250000,
+			InodesFree:      1200,
+			Tasks:           312,
+			PIDMax:          4194304,
+			ThreadsMax:      126000,
+			PIDPercent:      0.01,
+			ThreadPercent:   0.25,
+		},
+		ProcStats: []models.ProcessStats{
+			{
+				PID:        1,
+				Name:       "systemd",
+				Command:    "/sbin/init",
+				User:       "root",
+				State:      "S",
+				Threads:    1,
+				RSS:        12 * 1024 * 1024, // 12 MB
+				CPUTime:    90 * time.Second,
+				CPUPercent: 0.1,
+				MemPercent: 0.07,
+			},
+			{
+				PID:        4242,
+				PPID:       1,
+				Name:       "postgres",
+				Command:    "/usr/lib/postgresql/16/bin/postgres -D /var/lib/postgresql",
+				User:       "postgres",
+				State:      "R",
+				Threads:    8,
+				RSS:        512 * 1024 * 1024, // 512 MB
+				CPUTime:    2 * time.Hour,
+				CPUPercent: 35.0,
+				MemPercent: 3.1,
 			},
 		},
 	}
 }
 
+// GetHostStats returns mock host statistics or an error
+func (m *MockStatsProvider) GetHostStats() (*models.HostStats, error) {
+	if m.HostError != nil {
+		return nil, m.HostError
+	}
+	return m.HostStats, nil
+}
+
 // GetCPUStats returns mock CPU statistics or an error
 func (m *MockStatsProvider) GetCPUStats() (*models.CPUStats, error) {
 	if m.CPUError != nil {
@@ -73,6 +270,14 @@ func (m *MockStatsProvider) GetDiskStats() ([]models.DiskStats, error) {
 	return m.DiskStats, nil
 }
 
+// GetDiskIOStats returns mock disk I/O statistics or an error
+func (m *MockStatsProvider) GetDiskIOStats() ([]models.DiskIOStats, error) {
+	if m.DiskIOError != nil {
+		return nil, m.DiskIOError
+	}
+	return m.DiskIOStats, nil
+}
+
 // GetNetworkStats returns mock network statistics or an error
 func (m *MockStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 	if m.NetError != nil {
@@ -80,3 +285,59 @@ func (m *MockStatsProvider) GetNetworkStats() ([]models.NetworkStats, error) {
 	}
 	return m.NetStats, nil
 }
+
+// GetPressureStats returns mock pressure stall information or an error
+func (m *MockStatsProvider) GetPressureStats() (*models.PressureStats, error) {
+	if m.PSIError != nil {
+		return nil, m.PSIError
+	}
+	return m.Pressure, nil
+}
+
+// GetCgroupStats returns mock cgroup statistics or an error
+func (m *MockStatsProvider) GetCgroupStats() (*models.CgroupStats, error) {
+	if m.CgroupError != nil {
+		return nil, m.CgroupError
+	}
+	return m.Cgroup, nil
+}
+
+// GetSensorStats returns mock sensor readings or an error
+func (m *MockStatsProvider) GetSensorStats() (*models.SensorStats, error) {
+	if m.SensorError != nil {
+		return nil, m.SensorError
+	}
+	return m.Sensors, nil
+}
+
+// GetSocketStats returns mock socket statistics or an error
+func (m *MockStatsProvider) GetSocketStats() (*models.SocketStats, error) {
+	if m.SocketError != nil {
+		return nil, m.SocketError
+	}
+	return m.Sockets, nil
+}
+
+// GetActivityStats returns mock kernel activity counters or an error
+func (m *MockStatsProvider) GetActivityStats() (*models.ActivityStats, error) {
+	if m.ActivityError != nil {
+		return nil, m.ActivityError
+	}
+	return m.Activity, nil
+}
+
+// GetKernelTableStats returns mock kernel table usage or an error
+func (m *MockStatsProvider) GetKernelTableStats() (*models.KernelTableStats, error) {
+	if m.KernelError != nil {
+		return nil, m.KernelError
+	}
+	return m.Kernel, nil
+}
+
+// GetProcessStats returns mock process statistics or an error
+func (m *MockStatsProvider) GetProcessStats() ([]models.ProcessStats, error) {
+	if m.ProcError != nil {
+		return nil, m.ProcError
+	}
+	return m.ProcStats, nil
+}
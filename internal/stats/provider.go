@@ -7,9 +7,27 @@ import (
 	"github.com/sysmon/system-monitor-cli/internal/collector"
 )
 
+// Default pseudo-filesystem roots
+const (
+	DefaultProcRoot = "/proc"
+	DefaultSysRoot  = "/sys"
+	DefaultHostRoot = "/"
+)
+
+// Options configures the platform stats provider
+type Options struct {
+	ProcRoot string // Root of the procfs mount (Linux only, default /proc)
+	SysRoot  string // Root of the sysfs mount (Linux only, default /sys)
+	HostRoot string // Mount of the host's root filesystem, for disk usage (Linux only, default /)
+
+	// CgroupPath selects the cgroup v2 group to account, relative to the
+	// cgroup2 mount. Empty means the cgroup of the sysmon process (Linux only).
+	CgroupPath string
+}
+
 // NewProvider creates the appropriate SystemStatsProvider for the current OS
-func NewProvider() (collector.SystemStatsProvider, error) {
-	provider := newPlatformProvider()
+func NewProvider(opts Options) (collector.SystemStatsProvider, error) {
+	provider := newPlatformProvider(opts)
 	if provider == nil {
 		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 	}
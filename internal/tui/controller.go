@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/config"
+	"github.com/sysmon/system-monitor-cli/internal/models"
+	"github.com/sysmon/system-monitor-cli/internal/render"
+)
+
+// Action tells the monitor what to do after a key press
+type Action int
+
+// Actions returned by Controller.HandleKey
+const (
+	ActionNone    Action = iota // Nothing changed
+	ActionRedraw                // The view changed; redraw the last metrics
+	ActionRestart               // The interval or sort order changed; restart collection
+	ActionQuit                  // Stop monitoring
+)
+
+// intervalSteps are the refresh intervals cycled through by + and -
+var intervalSteps = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	1 * time.Minute,
+}
+
+// Controller maps key presses to changes of the interactive view
+type Controller struct {
+	view     render.View
+	disks    int // Length of the disk list in the last metrics
+	networks int // Length of the interface list in the last metrics
+	rows     int // List entries the display shows at once
+}
+
+// NewController creates a controller starting from the configured
+// interval and process sort order
+func NewController(interval time.Duration, sortBy string) *Controller {
+	return &Controller{
+		view: render.View{
+			Interactive: true,
+			Focus:       render.SectionDisk,
+			Sort:        sortBy,
+			Interval:    interval,
+		},
+		rows: render.DefaultScrollRows,
+	}
+}
+
+// SetScrollRows sets the number of list entries the display shows at
+// once, which sets the page size and bounds scrolling
+func (c *Controller) SetScrollRows(rows int) {
+	c.rows = max(rows, 1)
+	c.view.DiskOffset = c.clampOffset(c.view.DiskOffset, c.disks)
+	c.view.NetworkOffset = c.clampOffset(c.view.NetworkOffset, c.networks)
+}
+
+// View returns the current view state
+func (c *Controller) View() render.View {
+	return c.view
+}
+
+// Interval returns the selected refresh interval
+func (c *Controller) Interval() time.Duration {
+	return c.view.Interval
+}
+
+// Sort returns the selected table sort order
+func (c *Controller) Sort() string {
+	return c.view.Sort
+}
+
+// Paused reports whether display updates are paused
+func (c *Controller) Paused() bool {
+	return c.view.Paused
+}
+
+// Observe records the list lengths of new metrics, which bound scrolling
+func (c *Controller) Observe(metrics *models.Metrics) {
+	c.disks = len(metrics.Disk)
+	c.networks = len(metrics.Network)
+	c.view.DiskOffset = c.clampOffset(c.view.DiskOffset, c.disks)
+	c.view.NetworkOffset = c.clampOffset(c.view.NetworkOffset, c.networks)
+}
+
+// HandleKey applies a key press to the view
+func (c *Controller) HandleKey(key Key) Action {
+	switch key.Type {
+	case KeyCtrlC:
+		return ActionQuit
+	case KeyEsc:
+		if !c.view.ShowHelp {
+			return ActionNone
+		}
+		c.view.ShowHelp = false
+		return ActionRedraw
+	case KeyTab:
+		if c.view.Focus == render.SectionDisk {
+			c.view.Focus = render.SectionNetwork
+		} else {
+			c.view.Focus = render.SectionDisk
+		}
+		return ActionRedraw
+	case KeyUp:
+		return c.scroll(-1)
+	case KeyDown:
+		return c.scroll(1)
+	case KeyPageUp:
+		return c.scroll(-c.rows)
+	case KeyPageDown:
+		return c.scroll(c.rows)
+	case KeyRune:
+		return c.handleRune(key.Rune)
+	}
+	return ActionNone
+}
+
+// handleRune applies a character key press to the view
+func (c *Controller) handleRune(r rune) Action {
+	switch r {
+	case 'q', 'Q':
+		return ActionQuit
+	case ' ':
+		c.view.Paused = !c.view.Paused
+		return ActionRedraw
+	case 'h', 'H', '?':
+		c.view.ShowHelp = !c.view.ShowHelp
+		return ActionRedraw
+	case '+', '=':
+		return c.setInterval(slowerInterval(c.view.Interval))
+	case '-', '_':
+		return c.setInterval(fasterInterval(c.view.Interval))
+	case 'c':
+		return c.toggle(render.SectionCPU)
+	case 'm':
+		return c.toggle(render.SectionMemory)
+	case 'd':
+		return c.toggle(render.SectionDisk)
+	case 'n':
+		return c.toggle(render.SectionNetwork)
+	case 'p':
+		return c.toggle(render.SectionProcesses)
+	case 'k':
+		return c.scroll(-1)
+	case 'j':
+		return c.scroll(1)
+	case 's':
+		if c.view.Sort == config.ProcessSortMemory {
+			c.view.Sort = config.ProcessSortCPU
+		} else {
+			c.view.Sort = config.ProcessSortMemory
+		}
+		return ActionRestart
+	}
+	return ActionNone
+}
+
+// toggle shows or hides a section
+func (c *Controller) toggle(section render.Section) Action {
+	c.view.Hidden[section] = !c.view.Hidden[section]
+	return ActionRedraw
+}
+
+// scroll moves the focused list by delta entries
+func (c *Controller) scroll(delta int) Action {
+	if c.view.Focus == render.SectionNetwork {
+		c.view.NetworkOffset = c.clampOffset(c.view.NetworkOffset+delta, c.networks)
+	} else {
+		c.view.DiskOffset = c.clampOffset(c.view.DiskOffset+delta, c.disks)
+	}
+	return ActionRedraw
+}
+
+// setInterval changes the refresh interval if it differs from the current one
+func (c *Controller) setInterval(interval time.Duration) Action {
+	if interval == c.view.Interval {
+		return ActionNone
+	}
+	c.view.Interval = interval
+	return ActionRestart
+}
+
+// clampOffset limits a scroll offset so the last page of a list of n
+// entries stays full
+func (c *Controller) clampOffset(offset, n int) int {
+	if offset > n-c.rows {
+		offset = n - c.rows
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// slowerInterval returns the next longer interval step, or d itself if
+// it is already the longest
+func slowerInterval(d time.Duration) time.Duration {
+	for _, step := range intervalSteps {
+		if step > d {
+			return step
+		}
+	}
+	return d
+}
+
+// fasterInterval returns the next shorter interval step, or d itself if
+// it is already the shortest
+func fasterInterval(d time.Duration) time.Duration {
+	for i := len(intervalSteps) - 1; i >= 0; i-- {
+		if intervalSteps[i] < d {
+			return intervalSteps[i]
+		}
+	}
+	return d
+}
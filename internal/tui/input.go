@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Input reads key presses from a terminal switched into raw mode
+type Input struct {
+	fd    int
+	state *term.State
+	keys  chan Key
+	once  sync.Once
+}
+
+// NewInput puts the terminal behind f into raw mode and starts reading
+// key presses from it. Close must be called to restore the terminal.
+func NewInput(f *os.File) (*Input, error) {
+	fd := int(f.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("interactive mode requires a terminal on standard input")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable raw terminal mode: %w", err)
+	}
+
+	in := &Input{
+		fd:    fd,
+		state: state,
+		keys:  make(chan Key, 16),
+	}
+	go in.read(f)
+	return in, nil
+}
+
+// Keys returns the channel of key presses. It is closed when the input
+// reaches end of file or fails.
+func (in *Input) Keys() <-chan Key {
+	return in.keys
+}
+
+// Close restores the terminal to the mode it was in before NewInput
+func (in *Input) Close() error {
+	var err error
+	in.once.Do(func() {
+		err = term.Restore(in.fd, in.state)
+	})
+	return err
+}
+
+// read forwards key presses until the terminal is closed. Reads block,
+// so the goroutine lives until the process exits.
+func (in *Input) read(f *os.File) {
+	defer close(in.keys)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			in.keys <- key
+		}
+	}
+}
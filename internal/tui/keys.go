@@ -0,0 +1,87 @@
+// Package tui implements keyboard input for the interactive terminal mode.
+package tui
+
+import "unicode/utf8"
+
+// KeyType classifies a key press
+type KeyType int
+
+// Key types recognised in raw terminal input
+const (
+	KeyRune KeyType = iota // A printable character, see Key.Rune
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyTab
+	KeyEsc
+	KeyCtrlC
+	KeyUnknown // An unrecognised control character or escape sequence
+)
+
+// Key is a single key press read from the terminal
+type Key struct {
+	Type KeyType
+	Rune rune // Character for KeyRune
+}
+
+// escapeSequences maps the CSI and SS3 sequences sent by common
+// terminals to key types, without the leading ESC
+var escapeSequences = map[string]KeyType{
+	"[A":  KeyUp,
+	"[B":  KeyDown,
+	"OA":  KeyUp, // Application cursor mode
+	"OB":  KeyDown,
+	"[5~": KeyPageUp,
+	"[6~": KeyPageDown,
+}
+
+// parseKeys decodes the key presses in a chunk of raw terminal input.
+// Terminals write an escape sequence in a single chunk, so an ESC at the
+// end of the chunk is the Esc key itself.
+func parseKeys(data []byte) []Key {
+	var keys []Key
+
+	for len(data) > 0 {
+		switch b := data[0]; {
+		case b == 0x1b:
+			key, n := parseEscape(data[1:])
+			keys = append(keys, key)
+			data = data[1+n:]
+		case b == 0x03:
+			keys = append(keys, Key{Type: KeyCtrlC})
+			data = data[1:]
+		case b == '\t':
+			keys = append(keys, Key{Type: KeyTab})
+			data = data[1:]
+		case b < 0x20 || b == 0x7f:
+			keys = append(keys, Key{Type: KeyUnknown})
+			data = data[1:]
+		default:
+			r, n := utf8.DecodeRune(data)
+			keys = append(keys, Key{Type: KeyRune, Rune: r})
+			data = data[n:]
+		}
+	}
+
+	return keys
+}
+
+// parseEscape decodes the escape sequence following an ESC byte and
+// returns the key and the number of bytes consumed
+func parseEscape(data []byte) (Key, int) {
+	if len(data) == 0 || (data[0] != '[' && data[0] != 'O') {
+		return Key{Type: KeyEsc}, 0
+	}
+
+	// A sequence ends with its first byte in the range 0x40-0x7e
+	for i := 1; i < len(data); i++ {
+		if data[i] >= 0x40 && data[i] <= 0x7e {
+			if keyType, ok := escapeSequences[string(data[:i+1])]; ok {
+				return Key{Type: keyType}, i + 1
+			}
+			return Key{Type: KeyUnknown}, i + 1
+		}
+	}
+	return Key{Type: KeyUnknown}, len(data)
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sysmon/system-monitor-cli/internal/models"
+	"github.com/sysmon/system-monitor-cli/internal/render"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Key
+	}{
+		{"runes", "q+", []Key{{Type: KeyRune, Rune: 'q'}, {Type: KeyRune, Rune: '+'}}},
+		{"arrows", "\x1b[A\x1b[B", []Key{{Type: KeyUp}, {Type: KeyDown}}},
+		{"application arrows", "\x1bOA", []Key{{Type: KeyUp}}},
+		{"page keys", "\x1b[5~\x1b[6~", []Key{{Type: KeyPageUp}, {Type: KeyPageDown}}},
+		{"lone escape", "\x1b", []Key{{Type: KeyEsc}}},
+		{"escape then rune", "\x1bq", []Key{{Type: KeyEsc}, {Type: KeyRune, Rune: 'q'}}},
+		{"unknown sequence", "\x1b[15~s", []Key{{Type: KeyUnknown}, {Type: KeyRune, Rune: 's'}}},
+		{"control keys", "\t\x03\x01", []Key{{Type: KeyTab}, {Type: KeyCtrlC}, {Type: KeyUnknown}}},
+		{"utf-8", "é", []Key{{Type: KeyRune, Rune: 'é'}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestControllerInterval(t *testing.T) {
+	c := NewController(3*time.Second, "cpu")
+
+	// An interval between steps moves to the neighbouring step
+	if action := c.HandleKey(Key{Type: KeyRune, Rune: '+'}); action != ActionRestart || c.Interval() != 5*time.Second {
+		t.Errorf("after + action = %v interval = %v, want restart and 5s", action, c.Interval())
+	}
+	c.HandleKey(Key{Type: KeyRune, Rune: '-'})
+	if c.Interval() != 2*time.Second {
+		t.Errorf("after - interval = %v, want 2s", c.Interval())
+	}
+
+	for i := 0; i < 10; i++ {
+		c.HandleKey(Key{Type: KeyRune, Rune: '-'})
+	}
+	if action := c.HandleKey(Key{Type: KeyRune, Rune: '-'}); action != ActionNone || c.Interval() != 250*time.Millisecond {
+		t.Errorf("at minimum action = %v interval = %v, want none and 250ms", action, c.Interval())
+	}
+}
+
+func TestControllerScroll(t *testing.T) {
+	c := NewController(time.Second, "cpu")
+	c.Observe(&models.Metrics{
+		Disk:    make([]models.DiskStats, 6),
+		Network: make([]models.NetworkStats, 2),
+	})
+
+	// Six disks with four visible allow two steps of scrolling
+	for i := 0; i < 5; i++ {
+		c.HandleKey(Key{Type: KeyDown})
+	}
+	if got := c.View().DiskOffset; got != 2 {
+		t.Errorf("DiskOffset = %d, want 2", got)
+	}
+
+	// The interface list fits, so it does not scroll
+	c.HandleKey(Key{Type: KeyTab})
+	c.HandleKey(Key{Type: KeyDown})
+	if v := c.View(); v.Focus != render.SectionNetwork || v.NetworkOffset != 0 {
+		t.Errorf("Focus/NetworkOffset = %v/%d, want network/0", v.Focus, v.NetworkOffset)
+	}
+
+	// A shrinking list clamps the offset
+	c.Observe(&models.Metrics{Disk: make([]models.DiskStats, 5)})
+	if got := c.View().DiskOffset; got != 1 {
+		t.Errorf("DiskOffset after shrink = %d, want 1", got)
+	}
+
+	// A taller window shows the whole list, so the offset resets
+	c.SetScrollRows(5)
+	if got := c.View().DiskOffset; got != 0 {
+		t.Errorf("DiskOffset after growing the window = %d, want 0", got)
+	}
+}
+
+func TestControllerKeys(t *testing.T) {
+	c := NewController(time.Second, "cpu")
+
+	if action := c.HandleKey(Key{Type: KeyRune, Rune: 'p'}); action != ActionRedraw || !c.View().Hidden[render.SectionProcesses] {
+		t.Errorf("p: action = %v hidden = %v, want redraw and hidden", action, c.View().Hidden)
+	}
+	if action := c.HandleKey(Key{Type: KeyRune, Rune: 's'}); action != ActionRestart || c.Sort() != "memory" {
+		t.Errorf("s: action = %v sort = %q, want restart and memory", action, c.Sort())
+	}
+	if c.HandleKey(Key{Type: KeyRune, Rune: ' '}); !c.Paused() {
+		t.Error("space did not pause")
+	}
+	if c.HandleKey(Key{Type: KeyRune, Rune: '?'}); !c.View().ShowHelp {
+		t.Error("? did not show help")
+	}
+	if c.HandleKey(Key{Type: KeyEsc}); c.View().ShowHelp {
+		t.Error("Esc did not hide help")
+	}
+	if action := c.HandleKey(Key{Type: KeyCtrlC}); action != ActionQuit {
+		t.Errorf("Ctrl+C action = %v, want quit", action)
+	}
+	if action := c.HandleKey(Key{Type: KeyRune, Rune: 'q'}); action != ActionQuit {
+		t.Errorf("q action = %v, want quit", action)
+	}
+}